@@ -0,0 +1,50 @@
+package envlookup
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The vocabulary of bool values accepted by default.
+var (
+	defaultTruthy = []string{"true", "t", "1", "yes", "y", "on", "enabled"}
+	defaultFalsy  = []string{"false", "f", "0", "no", "n", "off", "disabled"}
+)
+
+// BoolValues sets the values that bool values are parsed from, in
+// place of the default vocabulary of true/false, t/f, 1/0, yes/no,
+// y/n, on/off and enabled/disabled. Values are matched ignoring case.
+// It is intended for use such as
+//
+//	b, err := envlookup.With(envlookup.BoolValues([]string{"ja"}, []string{"nein"})).Bool("VERBOSE")
+func BoolValues(truthy, falsy []string) Option {
+	return func(e *Env) {
+		e.truthy, e.falsy = truthy, falsy
+	}
+}
+
+// StrictBool restricts bool values to true and false, in any case.
+func StrictBool() Option {
+	return BoolValues([]string{"true"}, []string{"false"})
+}
+
+// parseBool parses v as a bool value in the vocabulary of e. The error
+// lists the accepted values.
+func (e *Env) parseBool(v string) (bool, error) {
+	truthy, falsy := e.truthy, e.falsy
+	if truthy == nil && falsy == nil {
+		truthy, falsy = defaultTruthy, defaultFalsy
+	}
+	for _, t := range truthy {
+		if strings.EqualFold(v, t) {
+			return true, nil
+		}
+	}
+	for _, f := range falsy {
+		if strings.EqualFold(v, f) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("\"%s\" is not parseable as bool value (accepted values are %s for true and %s for false)",
+		v, strings.Join(truthy, ", "), strings.Join(falsy, ", "))
+}
@@ -0,0 +1,78 @@
+package envlookup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestBoolVocabulary(t *testing.T) {
+	tests := map[string]bool{
+		"true": true, "T": true, "1": true, "Yes": true, "y": true, "ON": true, "enabled": true,
+		"false": false, "F": false, "0": false, "No": false, "n": false, "OFF": false, "disabled": false,
+	}
+	for v, want := range tests {
+		b, err := envlookup.New(envlookup.MapSource{"VERBOSE": v}).Bool("VERBOSE")
+		if b != want || err != nil {
+			t.Errorf("%q should be parsed as %t: got %t, %v", v, want, b, err)
+		}
+	}
+
+	_, err := envlookup.New(envlookup.MapSource{"VERBOSE": "maybe"}).Bool("VERBOSE")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "yes") || !strings.Contains(err.Error(), "disabled") {
+		t.Error("error should list the accepted values", err)
+	}
+}
+
+func TestStrictBool(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"VERBOSE": "TRUE", "QUIET": "yes"}, envlookup.StrictBool())
+	if b, err := env.Bool("VERBOSE"); !b || err != nil {
+		t.Error("true should be accepted", b, err)
+	}
+
+	_, err := env.Bool("QUIET")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+	if err == nil || strings.Contains(err.Error(), "yes,") {
+		t.Error("error should list the strict values only", err)
+	}
+}
+
+func TestBoolValues(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"VERBOSE": "Ja", "QUIET": "nein", "OTHER": "yes"},
+		envlookup.BoolValues([]string{"ja"}, []string{"nein"}))
+	if b, err := env.Bool("VERBOSE"); !b || err != nil {
+		t.Error("truthy value should be accepted", b, err)
+	}
+	if b, err := env.Bool("QUIET"); b || err != nil {
+		t.Error("falsy value should be accepted", b, err)
+	}
+	if _, err := env.Bool("OTHER"); err == nil {
+		t.Error("default vocabulary should be replaced")
+	}
+	if v, err := env.BoolSlice("VERBOSE"); len(v) != 1 || !v[0] || err != nil {
+		t.Error("bool elements should use the vocabulary", v, err)
+	}
+}
+
+func TestLoadStrictBool(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"VERBOSE": "on"})
+	var v struct {
+		Verbose bool `env:"VERBOSE"`
+	}
+	if err := env.Load(&v); !v.Verbose || err != nil {
+		t.Error("field should be loaded", v, err)
+	}
+
+	var strict struct {
+		Verbose bool `env:"VERBOSE" strictbool:"true"`
+	}
+	if err := env.Load(&strict); err == nil {
+		t.Error("strict field should not accept on")
+	}
+}
@@ -0,0 +1,166 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a size in bytes, such as a memory limit or a buffer
+// size. It is parsed from values such as "512MiB" or "1.5GB" and
+// formatted the same way.
+type ByteSize uint64
+
+// Common byte sizes, with SI (powers of 1000) and IEC (powers of 1024)
+// units.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+var byteUnits = map[string]ByteSize{
+	"":  Byte,
+	"b": Byte,
+
+	"k": KB, "kb": KB,
+	"m": MB, "mb": MB,
+	"g": GB, "gb": GB,
+	"t": TB, "tb": TB,
+	"p": PB, "pb": PB,
+	"e": EB, "eb": EB,
+
+	"ki": KiB, "kib": KiB,
+	"mi": MiB, "mib": MiB,
+	"gi": GiB, "gib": GiB,
+	"ti": TiB, "tib": TiB,
+	"pi": PiB, "pib": PiB,
+	"ei": EiB, "eib": EiB,
+}
+
+// ParseByteSize parses a byte size, a non-negative number followed by
+// an optional unit. SI units (kB, MB, GB, TB, PB and EB) are powers of
+// 1000 and IEC units (KiB, MiB, GiB, TiB, PiB and EiB) powers of 1024.
+// Units are case-insensitive, and the trailing B may be omitted, as in
+// "64k" or "2Gi". A number without unit is a number of bytes.
+// Fractional numbers such as "1.5GB" are rounded down to whole bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	num := strings.TrimSpace(s)
+	i := strings.IndexFunc(num, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	unit := ""
+	if i >= 0 {
+		num, unit = num[:i], strings.TrimSpace(num[i:])
+	}
+	mult, ok := byteUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit \"%s\" in byte size \"%s\"", unit, s)
+	}
+	if num == "" {
+		return 0, fmt.Errorf("invalid byte size \"%s\"", s)
+	}
+
+	if !strings.Contains(num, ".") {
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, byteSizeError(s, err)
+		}
+		hi, lo := bits.Mul64(n, uint64(mult))
+		if hi != 0 {
+			return 0, byteSizeError(s, strconv.ErrRange)
+		}
+		return ByteSize(lo), nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, byteSizeError(s, err)
+	}
+	f *= float64(mult)
+	if f >= math.MaxUint64 {
+		return 0, byteSizeError(s, strconv.ErrRange)
+	}
+	return ByteSize(f), nil
+}
+
+func byteSizeError(s string, err error) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		err = ne.Err
+	}
+	return fmt.Errorf("invalid byte size \"%s\": %w", s, err)
+}
+
+// String formats b with the largest unit that divides it exactly,
+// preferring IEC units, such as "512MiB" or "1500MB". Sizes that no
+// unit divides are formatted in bytes, such as "1023B". The result can
+// be parsed by ParseByteSize.
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+	units := []struct {
+		size ByteSize
+		name string
+	}{
+		{EiB, "EiB"}, {PiB, "PiB"}, {TiB, "TiB"}, {GiB, "GiB"}, {MiB, "MiB"}, {KiB, "KiB"},
+		{EB, "EB"}, {PB, "PB"}, {TB, "TB"}, {GB, "GB"}, {MB, "MB"}, {KB, "kB"},
+	}
+	for _, u := range units {
+		if b%u.size == 0 {
+			return strconv.FormatUint(uint64(b/u.size), 10) + u.name
+		}
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using
+// ParseByteSize.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	if b == nil {
+		return errors.New("envlookup: UnmarshalText on nil *ByteSize")
+	}
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// Bytes retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed by ParseByteSize and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as a byte size,
+// ParseError will be returned. Otherwise the returned value will be
+// empty and NotFoundError will be returned.
+func Bytes(key string, def ...ByteSize) (ByteSize, error) {
+	return std.Bytes(key, def...)
+}
+
+// Bytes retrieves the value of the variable named by the key from the
+// source of e. See the package-level Bytes for details.
+func (e *Env) Bytes(key string, def ...ByteSize) (ByteSize, error) {
+	return GetFrom(e, key, def...)
+}
@@ -0,0 +1,93 @@
+package envlookup_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want envlookup.ByteSize
+	}{
+		{"0", 0},
+		{"1024", 1024},
+		{"512MiB", 512 * envlookup.MiB},
+		{"512 mib", 512 * envlookup.MiB},
+		{"2Gi", 2 * envlookup.GiB},
+		{"64k", 64 * envlookup.KB},
+		{"1.5GB", 1500 * envlookup.MB},
+		{"1.5KiB", 1536},
+		{"10B", 10},
+		{"16EiB", 0},
+	}
+	for _, test := range tests {
+		got, err := envlookup.ParseByteSize(test.in)
+		if test.in == "16EiB" {
+			if !errors.Is(err, strconv.ErrRange) {
+				t.Error("error should wrap strconv.ErrRange", test.in, err)
+			}
+			continue
+		}
+		if got != test.want || err != nil {
+			t.Error("byte size should be parsed", test.in, got, err)
+		}
+	}
+
+	for _, in := range []string{"", "MB", "12XB", "1.2.3MB", "-5MB"} {
+		if _, err := envlookup.ParseByteSize(in); err == nil {
+			t.Error("error should be returned", in)
+		}
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	tests := []struct {
+		in   envlookup.ByteSize
+		want string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{512 * envlookup.MiB, "512MiB"},
+		{1500 * envlookup.MB, "1500MB"},
+		{3 * envlookup.KB, "3kB"},
+	}
+	for _, test := range tests {
+		if s := test.in.String(); s != test.want {
+			t.Error("byte size should be formatted", test.want, s)
+		}
+		if b, err := envlookup.ParseByteSize(test.in.String()); b != test.in || err != nil {
+			t.Error("formatted byte size should parse", test.in, b, err)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"MEMORY_LIMIT": "512MiB", "BAD_LIMIT": "lots"})
+	if b, err := env.Bytes("MEMORY_LIMIT"); b != 512*envlookup.MiB || err != nil {
+		t.Error("byte size should be set", b, err)
+	}
+	if b, err := env.Bytes("BUFFER_SIZE", 4*envlookup.KiB); b != 4*envlookup.KiB || err != nil {
+		t.Error("default value should be set", b, err)
+	}
+	if _, err := env.Bytes("BUFFER_SIZE"); err == nil {
+		t.Error("error should be envlookup.NotFoundError", err)
+	} else if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+	if _, err := env.Bytes("BAD_LIMIT"); err == nil {
+		t.Error("error should be envlookup.ParseError", err)
+	} else if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	var v struct {
+		Limit envlookup.ByteSize `env:"MEMORY_LIMIT"`
+	}
+	if err := env.Load(&v); err != nil || v.Limit != 512*envlookup.MiB {
+		t.Error("byte size should be loaded", v, err)
+	}
+}
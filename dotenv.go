@@ -0,0 +1,335 @@
+package envlookup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SyntaxError indicates that a dotenv file could not be parsed.
+type SyntaxError struct {
+	Filename string
+	Line     int
+	Msg      string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("dotenv: line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("dotenv: %s:%d: %s", e.Filename, e.Line, e.Msg)
+}
+
+// ReadDotenv reads the dotenv file named by filename. The returned
+// MapSource can be used as the Source of an Env. See ParseDotenv for
+// the file format.
+func ReadDotenv(filename string) (MapSource, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := ParseDotenv(f)
+	if err, ok := err.(*SyntaxError); ok {
+		err.Filename = filename
+	}
+	return m, err
+}
+
+// LoadDotenv reads the dotenv files named by filenames and sets their
+// variables in the process environment. Variables that are already
+// present in the environment are not overridden, so the files only
+// supply values that are missing. If a variable is set in several
+// files, the first one wins.
+func LoadDotenv(filenames ...string) error {
+	for _, filename := range filenames {
+		m, err := ReadDotenv(filename)
+		if err != nil {
+			return err
+		}
+		for k, v := range m {
+			if _, exists := os.LookupEnv(k); exists {
+				continue
+			}
+			if err := os.Setenv(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseDotenv parses variables in dotenv format from r. Each variable
+// is defined on a line of the form
+//
+//	[export] KEY=VALUE
+//
+// Blank lines and lines starting with # are ignored. Values may be
+// unquoted, single-quoted or double-quoted:
+//
+//   - Unquoted values end at the end of the line or at a # preceded by
+//     whitespace, which starts a comment. Surrounding whitespace is
+//     trimmed. A backslash at the end of a line escapes the newline and
+//     continues the value on the next line.
+//   - Single-quoted values are taken literally and may span several
+//     lines.
+//   - Double-quoted values may span several lines and support the
+//     escape sequences \n, \r, \t, \", \\ and \$.
+//
+// In unquoted and double-quoted values, references of the form ${VAR}
+// or $VAR are replaced by the value of VAR, defined earlier in the same
+// input or, failing that, in the process environment. Undefined
+// variables are replaced by the empty string.
+//
+// If the input is malformed, SyntaxError will be returned, giving the
+// line of the problem.
+func ParseDotenv(r io.Reader) (MapSource, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotenvParser{src: string(b), line: 1, vars: MapSource{}}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.vars, nil
+}
+
+type dotenvParser struct {
+	src  string
+	pos  int
+	line int
+	vars MapSource
+}
+
+func (p *dotenvParser) errorf(format string, args ...any) error {
+	return &SyntaxError{Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipBlank skips spaces and tabs, but not newlines.
+func (p *dotenvParser) skipBlank() {
+	for c := p.peek(); c == ' ' || c == '\t'; c = p.peek() {
+		p.next()
+	}
+}
+
+// skipLine skips to the start of the next line.
+func (p *dotenvParser) skipLine() {
+	for !p.eof() {
+		if p.next() == '\n' {
+			return
+		}
+	}
+}
+
+func (p *dotenvParser) parse() error {
+	for {
+		for c := p.peek(); c == ' ' || c == '\t' || c == '\r' || c == '\n'; c = p.peek() {
+			p.next()
+		}
+		if p.eof() {
+			return nil
+		}
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+		if err := p.parseAssignment(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *dotenvParser) parseAssignment() error {
+	key := p.name()
+	if key == "export" && (p.peek() == ' ' || p.peek() == '\t') {
+		p.skipBlank()
+		key = p.name()
+	}
+	if key == "" {
+		return p.errorf("invalid variable name")
+	}
+	p.skipBlank()
+	if p.eof() || p.next() != '=' {
+		return p.errorf("expected \"=\" after \"%s\"", key)
+	}
+	p.skipBlank()
+
+	var (
+		v   string
+		err error
+	)
+	switch p.peek() {
+	case '\'':
+		v, err = p.singleQuoted()
+	case '"':
+		v, err = p.doubleQuoted()
+	default:
+		v, err = p.unquoted()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Only a comment may follow a value on the same line.
+	p.skipBlank()
+	switch p.peek() {
+	case 0, '\n', '\r', '#':
+		p.skipLine()
+	default:
+		return p.errorf("unexpected character %q after value of \"%s\"", p.peek(), key)
+	}
+
+	p.vars[key] = v
+	return nil
+}
+
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return true
+	case '0' <= c && c <= '9':
+		return !first
+	}
+	return false
+}
+
+// name reads a variable name and returns it, or the empty string if
+// there is none.
+func (p *dotenvParser) name() string {
+	start := p.pos
+	for !p.eof() && isNameChar(p.peek(), p.pos == start) {
+		p.next()
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *dotenvParser) singleQuoted() (string, error) {
+	line := p.line
+	p.next()
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end < 0 {
+		p.line = line
+		return "", p.errorf("unterminated single-quoted value")
+	}
+	v := p.src[p.pos : p.pos+end]
+	for end := p.pos + end; p.pos <= end; {
+		p.next()
+	}
+	return v, nil
+}
+
+func (p *dotenvParser) doubleQuoted() (string, error) {
+	line := p.line
+	p.next()
+	var b strings.Builder
+	for {
+		if p.eof() {
+			p.line = line
+			return "", p.errorf("unterminated double-quoted value")
+		}
+		c := p.next()
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				continue
+			}
+			switch e := p.next(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+		case '$':
+			if err := p.reference(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+}
+
+func (p *dotenvParser) unquoted() (string, error) {
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		if c == '\n' || c == '\r' {
+			break
+		}
+		if c == '#' && (p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+		p.next()
+		switch {
+		case c == '$':
+			if err := p.reference(&b); err != nil {
+				return "", err
+			}
+		case c == '\\' && strings.HasPrefix(p.src[p.pos:], "\n"):
+			p.next()
+		case c == '\\' && strings.HasPrefix(p.src[p.pos:], "\r\n"):
+			p.next()
+			p.next()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimRight(b.String(), " \t"), nil
+}
+
+// reference expands the variable reference following a $ into b. A $
+// not followed by a variable name is kept literally.
+func (p *dotenvParser) reference(b *strings.Builder) error {
+	braced := p.peek() == '{'
+	if braced {
+		p.next()
+	}
+	key := p.name()
+	if braced {
+		if key == "" || p.eof() || p.next() != '}' {
+			return p.errorf("invalid variable reference")
+		}
+	} else if key == "" {
+		b.WriteByte('$')
+		return nil
+	}
+
+	v, ok := p.vars[key]
+	if !ok {
+		v = os.Getenv(key)
+	}
+	b.WriteString(v)
+	return nil
+}
@@ -0,0 +1,131 @@
+package envlookup_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+const dotenv = `# Coltrane's classic quartet
+export JAZZ_PIANIST=McCoy Tyner
+JAZZ_BASSIST = Jimmy Garrison   # joined in 1961
+JAZZ_DRUMMER='Elvin ${JONES}'
+ALBUM="A Love Supreme"
+PARTS="Acknowledgement\nResolution\tPursuance"
+LINER_NOTES="Let us sing all songs
+to God"
+QUARTET=${JAZZ_PIANIST}, $JAZZ_BASSIST
+ESCAPED="\${JAZZ_PIANIST} costs \$5"
+HASHTAG=#1
+EMPTY=
+CONTINUED=Impulse!\
+Records
+`
+
+func TestParseDotenv(t *testing.T) {
+	m, err := envlookup.ParseDotenv(strings.NewReader(dotenv))
+	if err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	want := envlookup.MapSource{
+		"JAZZ_PIANIST": "McCoy Tyner",
+		"JAZZ_BASSIST": "Jimmy Garrison",
+		"JAZZ_DRUMMER": "Elvin ${JONES}",
+		"ALBUM":        "A Love Supreme",
+		"PARTS":        "Acknowledgement\nResolution\tPursuance",
+		"LINER_NOTES":  "Let us sing all songs\nto God",
+		"QUARTET":      "McCoy Tyner, Jimmy Garrison",
+		"ESCAPED":      "${JAZZ_PIANIST} costs $5",
+		"HASHTAG":      "#1",
+		"EMPTY":        "",
+		"CONTINUED":    "Impulse!Records",
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("values should be parsed\n got: %q\nwant: %q", m, want)
+	}
+}
+
+func TestParseDotenvInterpolateEnv(t *testing.T) {
+	m, err := envlookup.ParseDotenv(strings.NewReader("LEADER=${JAZZ_ARTIST}${EMPTY_JAZZ_ARTIST}"))
+	if err != nil || m["LEADER"] != "John Coltrane" {
+		t.Error("process environment should be interpolated", m, err)
+	}
+}
+
+func TestParseDotenvSyntaxErr(t *testing.T) {
+	tests := []struct {
+		input string
+		line  int
+	}{
+		{"A=1\n=2", 2},
+		{"A=1\nB 2", 2},
+		{"A=1\n\nB='unterminated\n\n", 3},
+		{"A=\"unterminated", 1},
+		{"A=\"quoted\" trailing", 1},
+		{"A=${B", 1},
+		{"A=1\n# comment\nB=${}", 3},
+	}
+	for _, test := range tests {
+		_, err := envlookup.ParseDotenv(strings.NewReader(test.input))
+		se, ok := err.(*envlookup.SyntaxError)
+		if !ok {
+			t.Error("error should be envlookup.SyntaxError", test.input, err)
+			continue
+		}
+		if se.Line != test.line {
+			t.Error("error should report the line", test.input, se)
+		}
+	}
+}
+
+func TestReadDotenv(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, ".env")
+	if err := os.WriteFile(filename, []byte(dotenv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := envlookup.ReadDotenv(filename)
+	if err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	s, err := envlookup.New(m).String("ALBUM")
+	if s != "A Love Supreme" || err != nil {
+		t.Error("dotenv file should be usable as source", s, err)
+	}
+
+	bad := filepath.Join(dir, "bad.env")
+	if err := os.WriteFile(bad, []byte("A=1\nB"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = envlookup.ReadDotenv(bad)
+	if se, ok := err.(*envlookup.SyntaxError); !ok || se.Filename != bad {
+		t.Error("error should be envlookup.SyntaxError with filename", err)
+	}
+
+	if _, err := envlookup.ReadDotenv(filepath.Join(dir, "missing.env")); !os.IsNotExist(err) {
+		t.Error("error should report missing file", err)
+	}
+}
+
+func TestLoadDotenv(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), ".env")
+	data := "JAZZ_ARTIST=Pharoah Sanders\nDOTENV_ALBUM=Karma\n"
+	if err := os.WriteFile(filename, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("DOTENV_ALBUM")
+
+	if err := envlookup.LoadDotenv(filename); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v := os.Getenv("DOTENV_ALBUM"); v != "Karma" {
+		t.Error("missing variable should be set", v)
+	}
+	if v := os.Getenv("JAZZ_ARTIST"); v != "John Coltrane" {
+		t.Error("existing variable should not be overridden", v)
+	}
+}
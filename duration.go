@@ -0,0 +1,204 @@
+package envlookup
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// DurationUnit sets the unit of durations given as a plain number,
+// such as "30", so that DurationUnit(time.Second) reads it as 30
+// seconds. By default such numbers, except 0, are rejected.
+func DurationUnit(unit time.Duration) Option {
+	return func(e *Env) {
+		e.durationUnit = unit
+	}
+}
+
+// parseDuration parses v as a duration in the format of
+// time.ParseDuration extended with days and weeks, as an ISO 8601
+// duration or as a number in the duration unit of e.
+func (e *Env) parseDuration(v string) (time.Duration, error) {
+	if e.durationUnit != 0 {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return scaleDuration(f, e.durationUnit, v)
+		}
+	}
+
+	s := strings.TrimLeft(v, "+-")
+	switch {
+	case strings.HasPrefix(s, "P"):
+		return parseISODuration(v)
+	case strings.ContainsAny(s, "dw"):
+		return parseDaysDuration(v)
+	}
+	return time.ParseDuration(v)
+}
+
+// parseDaysDuration parses durations such as "1w2d3h4m".
+func parseDaysDuration(v string) (time.Duration, error) {
+	s, neg := trimSign(v)
+	if s == "" {
+		return 0, invalidDuration(v)
+	}
+
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i <= 0 {
+			return 0, invalidDuration(v)
+		}
+		j := strings.IndexFunc(s[i:], func(r rune) bool {
+			return ('0' <= r && r <= '9') || r == '.'
+		})
+		if j < 0 {
+			j = len(s) - i
+		}
+		num, unit := s[:i], s[i:i+j]
+		s = s[i+j:]
+
+		var (
+			d   time.Duration
+			err error
+		)
+		switch unit {
+		case "d", "w":
+			var f float64
+			if f, err = strconv.ParseFloat(num, 64); err != nil {
+				return 0, invalidDuration(v)
+			}
+			size := day
+			if unit == "w" {
+				size = week
+			}
+			d, err = scaleDuration(f, size, v)
+		default:
+			d, err = time.ParseDuration(num + unit)
+		}
+		if err != nil {
+			return 0, invalidDuration(v)
+		}
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("duration \"%s\" out of range", v)
+		}
+		total += d
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// parseISODuration parses ISO 8601 durations such as "P1W", "P2DT3H"
+// and "PT4M5.5S". Years and months are not supported, as their length
+// varies.
+func parseISODuration(v string) (time.Duration, error) {
+	s, neg := trimSign(v)
+	s = strings.TrimPrefix(s, "P")
+	if s == "" || s == "T" {
+		return 0, invalidDuration(v)
+	}
+
+	var (
+		total   time.Duration
+		inTime  bool
+		lastIdx = -1
+	)
+	// Designators must appear in this order, at most once each.
+	order := "WDTHMS"
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime {
+				return 0, invalidDuration(v)
+			}
+			inTime = true
+			lastIdx = strings.IndexByte(order, 'T')
+			s = s[1:]
+			if s == "" {
+				return 0, invalidDuration(v)
+			}
+			continue
+		}
+
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if i <= 0 {
+			return 0, invalidDuration(v)
+		}
+		f, err := strconv.ParseFloat(strings.ReplaceAll(s[:i], ",", "."), 64)
+		if err != nil {
+			return 0, invalidDuration(v)
+		}
+
+		var size time.Duration
+		switch d := s[i]; {
+		case !inTime && d == 'W':
+			size = week
+		case !inTime && d == 'D':
+			size = day
+		case inTime && d == 'H':
+			size = time.Hour
+		case inTime && d == 'M':
+			size = time.Minute
+		case inTime && d == 'S':
+			size = time.Second
+		case !inTime && (d == 'Y' || d == 'M'):
+			return 0, fmt.Errorf("duration \"%s\": years and months are not supported", v)
+		default:
+			return 0, invalidDuration(v)
+		}
+		idx := strings.IndexByte(order, s[i])
+		if idx <= lastIdx {
+			return 0, invalidDuration(v)
+		}
+		lastIdx = idx
+
+		d, err := scaleDuration(f, size, v)
+		if err != nil {
+			return 0, err
+		}
+		if total > math.MaxInt64-d {
+			return 0, fmt.Errorf("duration \"%s\" out of range", v)
+		}
+		total += d
+		s = s[i+1:]
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// scaleDuration returns f units as a duration.
+func scaleDuration(f float64, unit time.Duration, v string) (time.Duration, error) {
+	d := f * float64(unit)
+	// float64(math.MaxInt64) rounds up to 1<<63, which is already out
+	// of range.
+	if math.IsNaN(d) || d >= math.MaxInt64 || d < math.MinInt64 {
+		return 0, fmt.Errorf("duration \"%s\" out of range", v)
+	}
+	return time.Duration(d), nil
+}
+
+// trimSign removes a leading sign from v and reports whether it was
+// negative.
+func trimSign(v string) (string, bool) {
+	if strings.HasPrefix(v, "-") {
+		return v[1:], true
+	}
+	return strings.TrimPrefix(v, "+"), false
+}
+
+func invalidDuration(v string) error {
+	return fmt.Errorf("invalid duration \"%s\"", v)
+}
@@ -0,0 +1,106 @@
+package envlookup_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestExtendedDuration(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"27m32s", 27*time.Minute + 32*time.Second},
+		{"0", 0},
+		{"7d", 7 * day},
+		{"1.5d", 36 * time.Hour},
+		{"2w", 14 * day},
+		{"1w2d3h4m", 9*day + 3*time.Hour + 4*time.Minute},
+		{"-1d12h", -36 * time.Hour},
+		{"PT15M", 15 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * day},
+		{"PT0.5S", 500 * time.Millisecond},
+		{"PT1,5S", 1500 * time.Millisecond},
+		{"-PT1M", -time.Minute},
+	}
+	for _, test := range tests {
+		env := envlookup.New(envlookup.MapSource{"TOKEN_TTL": test.in})
+		d, err := env.Duration("TOKEN_TTL")
+		if d != test.want || err != nil {
+			t.Error("duration should be parsed", test.in, d, err)
+		}
+	}
+}
+
+func TestExtendedDurationErr(t *testing.T) {
+	for _, in := range []string{"30", "d", "7dd", "7d3", "P", "PT", "P1Y", "P1M", "PT1D", "P1H", "PT1S2M", "P1DT", "1000000w"} {
+		env := envlookup.New(envlookup.MapSource{"TOKEN_TTL": in})
+		_, err := env.Duration("TOKEN_TTL")
+		if _, ok := err.(*envlookup.ParseError); !ok {
+			t.Error("error should be envlookup.ParseError", in, err)
+		}
+	}
+}
+
+func TestDurationUnit(t *testing.T) {
+	src := envlookup.MapSource{"TIMEOUT": "30", "BACKOFF": "1.5", "TTL": "7d"}
+	env := envlookup.New(src, envlookup.DurationUnit(time.Second))
+	if d, err := env.Duration("TIMEOUT"); d != 30*time.Second || err != nil {
+		t.Error("unitless duration should be read in seconds", d, err)
+	}
+	if d, err := env.Duration("TTL"); d != 7*24*time.Hour || err != nil {
+		t.Error("duration with unit should still be parsed", d, err)
+	}
+
+	env = env.With(envlookup.DurationUnit(time.Millisecond))
+	if d, err := env.Duration("BACKOFF"); d != 1500*time.Microsecond || err != nil {
+		t.Error("unitless duration should be read in milliseconds", d, err)
+	}
+
+	var v struct {
+		Timeout time.Duration `env:"TIMEOUT" unit:"ms"`
+	}
+	if err := envlookup.New(src).Load(&v); err != nil || v.Timeout != 30*time.Millisecond {
+		t.Error("unit tag should set the unit", v, err)
+	}
+}
+
+func TestLoadInvalidUnitTag(t *testing.T) {
+	var v struct {
+		Timeout time.Duration `env:"TIMEOUT" unit:"sec"`
+	}
+	err := envlookup.New(envlookup.MapSource{"TIMEOUT": "30"}).Load(&v)
+	var verr *envlookup.ValidationError
+	if !errors.As(err, &verr) || verr.Rule != "unit=sec" {
+		t.Error("error should be envlookup.ValidationError for the tag", err)
+	}
+}
+
+func TestDurationUnitRange(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"MAX":       "9223372036854775807",
+		"LARGE":     "9223372036854774784",
+		"MIN":       "-9223372036854775808",
+		"TOO_SMALL": "-9223372036854777856",
+	}, envlookup.DurationUnit(time.Nanosecond))
+
+	for _, key := range []string{"MAX", "TOO_SMALL"} {
+		_, err := env.Duration(key)
+		if _, ok := err.(*envlookup.ParseError); !ok {
+			t.Error("error should be envlookup.ParseError", key, err)
+		}
+	}
+	// The largest float64 below 1<<63.
+	if d, err := env.Duration("LARGE"); d != 9223372036854774784 || err != nil {
+		t.Error("duration in range should be parsed", d, err)
+	}
+	if d, err := env.Duration("MIN"); d != math.MinInt64 || err != nil {
+		t.Error("minimum duration should be parsed", d, err)
+	}
+}
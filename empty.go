@@ -0,0 +1,40 @@
+package envlookup
+
+import "fmt"
+
+// EmptyError indicates that an environment variable was set to the
+// empty string while empty values are treated as unset, see
+// EmptyAsUnset.
+type EmptyError struct {
+	Var string
+}
+
+func (e *EmptyError) Error() string {
+	return fmt.Sprintf("environment variable \"%s\" is empty", e.Var)
+}
+
+// EmptyAsUnset treats variables that are set to the empty string as if
+// they were not set, so that defaults apply to them. Without a
+// default, EmptyError is returned instead of NotFoundError. This suits
+// environments such as Kubernetes or docker-compose, which often
+// render unset values as empty strings. With Layers as the source,
+// layers where the variable is empty are skipped, so that lower layers
+// can supply the value.
+func EmptyAsUnset() Option {
+	return func(e *Env) {
+		e.emptyAsUnset = true
+	}
+}
+
+// Configure applies opts to the Env used by the package-level
+// functions. It is intended to be called once at program start, such
+// as
+//
+//	envlookup.Configure(envlookup.EmptyAsUnset())
+//
+// Configure must not be called concurrently with lookups.
+func Configure(opts ...Option) {
+	for _, opt := range opts {
+		opt(std)
+	}
+}
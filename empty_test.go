@@ -0,0 +1,65 @@
+package envlookup_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var emptySrc = envlookup.MapSource{"PORT": "", "HOST": "localhost"}
+
+func TestEmptyAsUnset(t *testing.T) {
+	env := envlookup.New(emptySrc)
+	if _, err := env.Int("PORT", 8080); err == nil {
+		t.Error("empty value should not be parseable by default")
+	}
+
+	env = env.With(envlookup.EmptyAsUnset())
+	if port, err := env.Int("PORT", 8080); port != 8080 || err != nil {
+		t.Error("default value should be returned", port, err)
+	}
+	if s, err := env.String("HOST", "example.com"); s != "localhost" || err != nil {
+		t.Error("value should be returned", s, err)
+	}
+
+	_, err := env.String("PORT")
+	if err, ok := err.(*envlookup.EmptyError); !ok || err.Var != "PORT" {
+		t.Error("error should be envlookup.EmptyError", err)
+	}
+	_, err = env.String("MISSING")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+}
+
+func TestLoadEmptyAsUnset(t *testing.T) {
+	var v struct {
+		Port int    `env:"PORT" default:"8080" emptyasunset:"true"`
+		Host string `env:"HOST"`
+	}
+	if err := envlookup.New(emptySrc).Load(&v); v.Port != 8080 || v.Host != "localhost" || err != nil {
+		t.Error("default value should be loaded", v, err)
+	}
+
+	var required struct {
+		Port int `env:"PORT" required:"true"`
+	}
+	err := envlookup.New(emptySrc, envlookup.EmptyAsUnset()).Load(&required)
+	var emptyErr *envlookup.EmptyError
+	if !errors.As(err, &emptyErr) {
+		t.Error("error should contain envlookup.EmptyError", err)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	envlookup.Configure(envlookup.Separator("!"))
+	defer envlookup.Configure(envlookup.Separator(","))
+
+	val, err := envlookup.Slice("RECORD_LABELS")
+	want := []string{"Impulse", ",Atlantic,Prestige,Blue Note"}
+	if !reflect.DeepEqual(val, want) || err != nil {
+		t.Errorf("value should be split: got %q, want %q", val, want)
+	}
+}
@@ -0,0 +1,113 @@
+package envlookup
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Enum retrieves the value of the environment variable named by the
+// key and maps it to one of choices, such as typed constants. If the
+// variable is present in the environment and is a key of choices, the
+// corresponding value is returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var is not one of the choices,
+// ParseError listing the choices will be returned. Otherwise the
+// returned value will be empty and NotFoundError will be returned.
+// Choices are matched exactly unless the IgnoreCase option is set. It
+// is intended for use such as
+//
+//	level, err := envlookup.Enum("LOG_LEVEL", map[string]slog.Level{
+//		"debug": slog.LevelDebug,
+//		"info":  slog.LevelInfo,
+//	}, slog.LevelInfo)
+func Enum[T any](key string, choices map[string]T, def ...T) (T, error) {
+	return EnumFrom(std, key, choices, def...)
+}
+
+// EnumFrom is like Enum but retrieves the variable from the source of
+// e.
+func EnumFrom[T any](e *Env, key string, choices map[string]T, def ...T) (T, error) {
+	var res T
+	key = e.varName(key)
+	v, err := e.lookup(key)
+	if err != nil {
+		if len(def) > 0 && missing(err) {
+			return def[0], nil
+		}
+		return res, err
+	}
+
+	res, err = matchChoice(e, v, choices)
+	if err != nil {
+		return res, &ParseError{key, e.redactError(err)}
+	}
+	if err := e.validate(key, v, reflect.ValueOf(&res).Elem()); err != nil {
+		var zero T
+		return zero, err
+	}
+	return res, nil
+}
+
+// RegisterEnum registers choices as the values of type T, so that Get
+// and Load map variables of type T as by Enum. It is safe for
+// concurrent use.
+func RegisterEnum[T any](choices map[string]T) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[t] = func(e *Env, v string) (any, error) {
+		return matchChoice(e, v, choices)
+	}
+}
+
+// IgnoreCase makes enum values match their choices ignoring case.
+func IgnoreCase() Option {
+	return func(e *Env) {
+		e.ignoreCase = true
+	}
+}
+
+// matchChoice returns the choice that v maps to. The error lists the
+// accepted values.
+func matchChoice[T any](e *Env, v string, choices map[string]T) (T, error) {
+	if c, ok := choices[v]; ok {
+		return c, nil
+	}
+	names := make([]string, 0, len(choices))
+	for name := range choices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if e.ignoreCase {
+		for _, name := range names {
+			if strings.EqualFold(v, name) {
+				return choices[name], nil
+			}
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("\"%s\" is not one of %s", v, strings.Join(names, ", "))
+}
+
+// enumTagParser returns a function mapping values to the choices of an
+// `enum:"name=value,..."` tag, whose values are parsed by parse.
+func (e *Env) enumTagParser(tag string, parse func(v string) (reflect.Value, error)) (func(v string) (reflect.Value, error), error) {
+	choices := map[string]reflect.Value{}
+	for _, pair := range strings.Split(tag, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid choice \"%s\"", pair)
+		}
+		pv, err := parse(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice \"%s\": %w", pair, err)
+		}
+		choices[name] = pv
+	}
+	return func(v string) (reflect.Value, error) {
+		return matchChoice(e, v, choices)
+	}, nil
+}
@@ -0,0 +1,101 @@
+package envlookup_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+type logLevel int
+
+const (
+	levelDebug logLevel = iota - 1
+	levelInfo
+	levelWarn
+)
+
+var levels = map[string]logLevel{"debug": levelDebug, "info": levelInfo, "warn": levelWarn}
+
+var enumSrc = envlookup.MapSource{"LOG_LEVEL": "warn", "UPPER_LEVEL": "DEBUG", "BAD_LEVEL": "verbose"}
+
+func TestEnum(t *testing.T) {
+	env := envlookup.New(enumSrc)
+	if l, err := envlookup.EnumFrom(env, "LOG_LEVEL", levels); l != levelWarn || err != nil {
+		t.Error("value should be mapped", l, err)
+	}
+
+	_, err := envlookup.EnumFrom(env, "BAD_LEVEL", levels)
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "debug, info, warn") {
+		t.Error("error should list the choices", err)
+	}
+
+	_, err = envlookup.EnumFrom(env, "UPPER_LEVEL", levels)
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("choices should be case-sensitive by default", err)
+	}
+	l, err := envlookup.EnumFrom(env.With(envlookup.IgnoreCase()), "UPPER_LEVEL", levels)
+	if l != levelDebug || err != nil {
+		t.Error("value should be mapped ignoring case", l, err)
+	}
+
+	l, err = envlookup.EnumFrom(env, "MISSING", levels, levelInfo)
+	if l != levelInfo || err != nil {
+		t.Error("default value should be returned", l, err)
+	}
+	_, err = envlookup.Enum("MISSING_LOG_LEVEL", levels)
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+}
+
+type color string
+
+func TestRegisterEnum(t *testing.T) {
+	envlookup.RegisterEnum(map[string]color{"red": "#f00", "green": "#0f0"})
+	env := envlookup.New(envlookup.MapSource{"COLOR": "Green", "COLORS": "red,green"}, envlookup.IgnoreCase())
+	if c, err := envlookup.GetFrom[color](env, "COLOR"); c != "#0f0" || err != nil {
+		t.Error("value should be mapped", c, err)
+	}
+	if c, err := envlookup.GetFrom[[]color](env, "COLORS"); len(c) != 2 || c[0] != "#f00" || err != nil {
+		t.Error("elements should be mapped", c, err)
+	}
+}
+
+func TestLoadEnumTag(t *testing.T) {
+	var v struct {
+		Level logLevel  `env:"LOG_LEVEL" enum:"debug=-1, info=0, warn=1"`
+		Upper *logLevel `env:"UPPER_LEVEL" enum:"debug=-1,info=0" ignorecase:"true"`
+	}
+	if err := envlookup.New(enumSrc).Load(&v); err != nil || v.Level != levelWarn || *v.Upper != levelDebug {
+		t.Error("fields should be mapped", v, err)
+	}
+
+	var bad struct {
+		Level logLevel `env:"BAD_LEVEL" enum:"debug=-1,info=0"`
+		Tag   logLevel `env:"LOG_LEVEL" enum:"debug=low"`
+	}
+	err := envlookup.New(enumSrc).Load(&bad)
+	var errs envlookup.Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatal("both fields should fail", err)
+	}
+	if _, ok := errs[0].(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", errs[0])
+	}
+	var valErr *envlookup.ValidationError
+	if !errors.As(errs[1], &valErr) || valErr.Rule != "enum=debug=low" || !strings.Contains(valErr.Error(), "invalid enum tag") {
+		t.Error("error should be envlookup.ValidationError for the tag", errs[1])
+	}
+
+	var unset struct {
+		Level logLevel `env:"UNSET_LEVEL" enum:"debug"`
+	}
+	if err := envlookup.New(enumSrc).Load(&unset); !errors.As(err, &valErr) {
+		t.Error("invalid tag should fail even if the variable is unset", err)
+	}
+}
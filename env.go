@@ -0,0 +1,234 @@
+package envlookup
+
+import "time"
+
+// Env retrieves and parses variables from a Source. The methods of Env
+// behave like the package-level functions of the same name, which use
+// an Env reading from the process environment.
+type Env struct {
+	src    Source
+	prefix string
+
+	sep       string
+	trim      bool
+	dropEmpty bool
+	quoted    bool
+	pairSep   string
+	kvSep     string
+
+	base        int
+	underscores bool
+
+	truthy     []string
+	falsy      []string
+	ignoreCase bool
+
+	emptyAsUnset bool
+	fileFallback bool
+	expand       bool
+	rules        []rule
+	badTags      []rule
+	sensitive    bool
+
+	durationUnit time.Duration
+	timeLayout   string
+}
+
+// Option configures how an Env looks up and parses variables.
+type Option func(e *Env)
+
+// New returns an Env that looks up variables in src, configured by
+// opts. If src is nil, variables are looked up in whatever OS is at
+// the time of the lookup.
+func New(src Source, opts ...Option) *Env {
+	e := &Env{src: src, sep: ",", pairSep: ",", kvSep: "=", timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+var std = New(nil)
+
+// With returns an Env that looks up variables in the process
+// environment, configured by opts. It is intended for use such as
+//
+//	labels, err := envlookup.With(envlookup.Separator(";")).Slice("LABELS")
+func With(opts ...Option) *Env {
+	return std.With(opts...)
+}
+
+// With returns a copy of e with opts applied.
+func (e *Env) With(opts ...Option) *Env {
+	c := *e
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &c
+}
+
+// WithPrefix returns an Env that prepends prefix to every key before
+// looking it up in the process environment. It is intended for use
+// such as
+//
+//	billing := envlookup.WithPrefix("BILLING_")
+//	host, err := billing.String("DB_HOST") // reads BILLING_DB_HOST
+func WithPrefix(prefix string) *Env {
+	return std.WithPrefix(prefix)
+}
+
+// WithPrefix returns a copy of e that prepends prefix to every key
+// before looking it up. Prefixes accumulate, so that
+// e.WithPrefix("BILLING_").WithPrefix("DB_") looks up keys prefixed
+// with BILLING_DB_. Errors name the prefixed variable.
+func (e *Env) WithPrefix(prefix string) *Env {
+	c := *e
+	c.prefix += prefix
+	return &c
+}
+
+// varName returns the name of the variable that the key refers to.
+func (e *Env) varName(key string) string {
+	return e.prefix + key
+}
+
+// srcLookup looks up the variable named by name in the source of e.
+// With the EmptyAsUnset option, empty values in a layer of Layers
+// don't hide the values of lower layers.
+func (e *Env) srcLookup(name string) (string, bool) {
+	if l, ok := e.source().(Layers); ok && e.emptyAsUnset {
+		v, _, ok := l.lookup(name, true)
+		return v, ok
+	}
+	return e.source().Lookup(name)
+}
+
+// source returns the source of e, which is OS if none was given.
+func (e *Env) source() Source {
+	if e.src == nil {
+		return OS
+	}
+	return e.src
+}
+
+// lookup retrieves the value of the variable named by name, expanded
+// if enabled by the Expand option.
+func (e *Env) lookup(name string) (string, error) {
+	v, err := e.lookupRaw(name)
+	if err != nil || !e.expand {
+		return v, err
+	}
+	return e.expandValue(v, []string{name})
+}
+
+// lookupRaw retrieves the raw value of the variable named by name, or
+// of the file named by its _FILE variable if enabled by the
+// FileFallback option. If the variable is missing,
+// NotFoundError or EmptyError is returned.
+func (e *Env) lookupRaw(name string) (string, error) {
+	v, exists := e.srcLookup(name)
+	empty := exists && v == "" && e.emptyAsUnset
+	if fv, ok, err := e.readFile(name, exists && !empty); ok {
+		if err != nil {
+			return "", err
+		}
+		v, exists, empty = fv, true, fv == "" && e.emptyAsUnset
+	}
+	if !exists {
+		return "", &NotFoundError{name}
+	}
+	if empty {
+		return "", &EmptyError{name}
+	}
+	return v, nil
+}
+
+// String retrieves the value of the variable named by the key from
+// the source of e. See the package-level String for details.
+func (e *Env) String(key string, def ...string) (string, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Slice retrieves the value of the variable named by the key from
+// the source of e. See the package-level Slice for details.
+func (e *Env) Slice(key string, def ...[]string) ([]string, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int for details.
+func (e *Env) Int(key string, def ...int) (int, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int64 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int64 for details.
+func (e *Env) Int64(key string, def ...int64) (int64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int8 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int8 for details.
+func (e *Env) Int8(key string, def ...int8) (int8, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int16 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int16 for details.
+func (e *Env) Int16(key string, def ...int16) (int16, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int32 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int32 for details.
+func (e *Env) Int32(key string, def ...int32) (int32, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Bool retrieves the value of the variable named by the key from
+// the source of e. See the package-level Bool for details.
+func (e *Env) Bool(key string, def ...bool) (bool, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Duration retrieves the value of the variable named by the key from
+// the source of e. See the package-level Duration for details.
+func (e *Env) Duration(key string, def ...time.Duration) (time.Duration, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Float64 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Float64 for details.
+func (e *Env) Float64(key string, def ...float64) (float64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint64 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Uint64 for details.
+func (e *Env) Uint64(key string, def ...uint64) (uint64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint retrieves the value of the variable named by the key from
+// the source of e. See the package-level Uint for details.
+func (e *Env) Uint(key string, def ...uint) (uint, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint8 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Uint8 for details.
+func (e *Env) Uint8(key string, def ...uint8) (uint8, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint16 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Uint16 for details.
+func (e *Env) Uint16(key string, def ...uint16) (uint16, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint32 retrieves the value of the variable named by the key from
+// the source of e. See the package-level Uint32 for details.
+func (e *Env) Uint32(key string, def ...uint32) (uint32, error) {
+	return GetFrom(e, key, def...)
+}
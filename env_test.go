@@ -0,0 +1,84 @@
+package envlookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var testEnv = envlookup.New(envlookup.MapSource{
+	"JAZZ_ARTIST":               "Miles Davis",
+	"PLAYED_WITH_JOHN_COLTRANE": "true",
+	"NO_OF_STUDIO_ALBUMS":       "48",
+	"RECORD_LABELS":             "Columbia,Prestige",
+	"LONGEST_RECORDED_TRACK":    "32m27s",
+	"TRUMPET_PRICE":             "4.5",
+	"BAD_NUMBER":                "many",
+})
+
+func TestEnvString(t *testing.T) {
+	val, err := testEnv.String("JAZZ_ARTIST")
+	if val != "Miles Davis" {
+		t.Error("value should be read from the source", val)
+	}
+	if err != nil {
+		t.Error("error should be nil", err)
+	}
+
+	// The process environment must not leak into the source.
+	val, err = testEnv.String("NO_OF_UNSIGNED_STUDIO_ALBUMS")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", val, err)
+	}
+
+	val, err = testEnv.String("EMPTY_JAZZ_ARTIST", "Chet Baker")
+	if val != "Chet Baker" {
+		t.Error("default value should be set", val)
+	}
+	if err != nil {
+		t.Error("error should be nil", err)
+	}
+}
+
+func TestEnvTypes(t *testing.T) {
+	if b, err := testEnv.Bool("PLAYED_WITH_JOHN_COLTRANE"); !b || err != nil {
+		t.Error("bool value should be parsed", b, err)
+	}
+	if i, err := testEnv.Int("NO_OF_STUDIO_ALBUMS"); i != 48 || err != nil {
+		t.Error("int value should be parsed", i, err)
+	}
+	if i, err := testEnv.Int64("NO_OF_STUDIO_ALBUMS"); i != 48 || err != nil {
+		t.Error("int64 value should be parsed", i, err)
+	}
+	if u, err := testEnv.Uint64("NO_OF_STUDIO_ALBUMS"); u != 48 || err != nil {
+		t.Error("uint64 value should be parsed", u, err)
+	}
+	if f, err := testEnv.Float64("TRUMPET_PRICE"); f != 4.5 || err != nil {
+		t.Error("float64 value should be parsed", f, err)
+	}
+	if d, err := testEnv.Duration("LONGEST_RECORDED_TRACK"); d != 32*time.Minute+27*time.Second || err != nil {
+		t.Error("duration value should be parsed", d, err)
+	}
+	if s, err := testEnv.Slice("RECORD_LABELS"); len(s) != 2 || err != nil {
+		t.Error("slice value should be parsed", s, err)
+	}
+	if _, err := testEnv.Int("BAD_NUMBER"); err == nil {
+		t.Error("error should be envlookup.ParseError", err)
+	} else if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestEnvLoad(t *testing.T) {
+	var v struct {
+		Name   string `env:"JAZZ_ARTIST"`
+		Albums int    `env:"NO_OF_STUDIO_ALBUMS"`
+	}
+	if err := testEnv.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Name != "Miles Davis" || v.Albums != 48 {
+		t.Error("values should be read from the source", v)
+	}
+}
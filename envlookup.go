@@ -1,16 +1,10 @@
 package envlookup
 
 import (
-	"errors"
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
 	"time"
 )
 
-const separator = ","
-
 // NotFoundError indicates that an environment variable was not found.
 type NotFoundError struct {
 	Var string
@@ -31,45 +25,37 @@ func (e *ParseError) Error() string {
 	return fmt.Sprintf("could not parse environment variable \"%s\": %s", e.Var, e.Err)
 }
 
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
 // String retrieves the value of the environment variable named by the
 // key. If the variable is present in the environment the value (which
 // may be empty) is returned and the error is nil. If the variable is
 // not present but a default value is supplied, that value will be
 // returned. Otherwise the returned value will be empty and
 // NotFoundError will be returned.
+//
+// With the EmptyAsUnset option, which can be set for all package-level
+// functions with Configure, empty values are treated as unset by all
+// functions of this package. With the FileFallback option, variables
+// that are not set are read from the file named by a variable with the
+// suffix _FILE if that is set.
 func String(key string, def ...string) (string, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res string
-		err := &NotFoundError{key}
-		return res, err
-	}
-	return v, nil
+	return std.String(key, def...)
 }
 
 // Slice retrieves the value of the environment variable named by the
 // key. If the variable is present in the environment the value (which
-// may be empty) is returned and the error is nil. If the variable is
-// not present but a default value is supplied, that value will be
-// returned. Otherwise the returned value will be empty and
-// NotFoundError will be returned.
+// may be empty) is split on commas and returned and the error is nil.
+// If the variable is not present but a default value is supplied, that
+// value will be returned. Otherwise the returned value will be empty
+// and NotFoundError will be returned. The splitting can be configured
+// with the Separator, TrimSpace, DropEmpty and Quoted options, see
+// With.
 func Slice(key string, def ...[]string) ([]string, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res []string
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	split := strings.Split(v, separator)
-
-	return split, nil
+	return std.Slice(key, def...)
 }
 
 // Int retrieves the value of the environment variable named by the
@@ -80,23 +66,7 @@ func Slice(key string, def ...[]string) ([]string, error) {
 // ParseError will be returned. Otherwise the returned value will be
 // empty and NotFoundError will be returned.
 func Int(key string, def ...int) (int, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res int
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	i, err := strconv.Atoi(v)
-	if err != nil {
-		var res int
-		err := &ParseError{key, err}
-		return res, err
-	}
-	return i, nil
+	return std.Int(key, def...)
 }
 
 // Int64 retrieves the value of the environment variable named by the
@@ -107,23 +77,43 @@ func Int(key string, def ...int) (int, error) {
 // ParseError will be returned. Otherwise the returned value will be
 // empty and NotFoundError will be returned.
 func Int64(key string, def ...int64) (int64, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res int64
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	i, err := strconv.ParseInt(v, 10, 64)
-	if err != nil {
-		var res int64
-		err := &ParseError{key, err}
-		return res, err
-	}
-	return i, nil
+	return std.Int64(key, def...)
+}
+
+// Int8 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as an int8 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as an int8 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Int8(key string, def ...int8) (int8, error) {
+	return std.Int8(key, def...)
+}
+
+// Int16 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as an int16 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as an int16 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Int16(key string, def ...int16) (int16, error) {
+	return std.Int16(key, def...)
+}
+
+// Int32 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as an int32 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as an int32 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Int32(key string, def ...int32) (int32, error) {
+	return std.Int32(key, def...)
 }
 
 // Bool retrieves the value of the environment variable named by the
@@ -133,24 +123,12 @@ func Int64(key string, def ...int64) (int64, error) {
 // returned. If the env var could could not be parsed as a bool value,
 // ParseError will be returned. Otherwise the returned value will be
 // empty and NotFoundError will be returned.
+//
+// The values true/false, t/f, 1/0, yes/no, y/n, on/off and
+// enabled/disabled are accepted, ignoring case. The vocabulary can be
+// changed with the BoolValues and StrictBool options.
 func Bool(key string, def ...bool) (bool, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res bool
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	b, err := strToBool(v)
-	if err != nil {
-		var res bool
-		err := &ParseError{key, err}
-		return res, err
-	}
-	return b, nil
+	return std.Bool(key, def...)
 }
 
 // Duration retrieves the value of the environment variable named by
@@ -160,23 +138,13 @@ func Bool(key string, def ...bool) (bool, error) {
 // will be returned. If the env var could could not be parsed as a
 // time.Duration value, ParseError will be returned. Otherwise the
 // returned value will be empty and NotFoundError will be returned.
+//
+// Besides the format of time.ParseDuration, days and weeks ("7d",
+// "1w2d12h") and ISO 8601 durations ("PT15M", "P1DT12H") are
+// accepted. Numbers without unit are accepted with the DurationUnit
+// option.
 func Duration(key string, def ...time.Duration) (time.Duration, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res time.Duration
-		err := &NotFoundError{key}
-		return res, err
-	}
-	d, err := time.ParseDuration(v)
-	if err != nil {
-		var res time.Duration
-		err := &ParseError{key, err}
-		return res, err
-	}
-	return d, nil
+	return std.Duration(key, def...)
 }
 
 // Float64 retrieves the value of the environment variable named by the
@@ -187,24 +155,7 @@ func Duration(key string, def ...time.Duration) (time.Duration, error) {
 // ParseError will be returned. Otherwise the returned value will be
 // empty and NotFoundError will be returned.
 func Float64(key string, def ...float64) (float64, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res float64
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	f, err := strconv.ParseFloat(v, 64)
-	if err != nil {
-		var res float64
-		err := &ParseError{key, err}
-		return res, err
-	}
-
-	return f, nil
+	return std.Float64(key, def...)
 }
 
 // Uint64 retrieves the value of the environment variable named by the
@@ -214,40 +165,53 @@ func Float64(key string, def ...float64) (float64, error) {
 // returned. Otherwise the returned value will be empty and
 // NotFoundError will be returned.
 func Uint64(key string, def ...uint64) (uint64, error) {
-	v, exists := os.LookupEnv(key)
-	if !exists {
-		if len(def) > 0 {
-			return def[0], nil
-		}
-		var res uint64
-		err := &NotFoundError{key}
-		return res, err
-	}
-
-	u, err := strconv.ParseUint(v, 0, 64)
-	if err != nil {
-		var res uint64
-		err := &ParseError{key, err}
-		return res, err
-	}
-
-	return u, nil
-}
-
-func strToBool(v string) (bool, error) {
-	switch strings.ToLower(v) {
-	case "false":
-		fallthrough
-	case "0":
-		return false, nil
-	case "true":
-		fallthrough
-	case "1":
-		return true, nil
-	default:
-		var res bool
-		errStr := fmt.Sprintf("\"%s\" is not parseable as bool value", v)
-		err := errors.New(errStr)
-		return res, err
-	}
+	return std.Uint64(key, def...)
+}
+
+// Uint retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as a uint value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as a uint value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Uint(key string, def ...uint) (uint, error) {
+	return std.Uint(key, def...)
+}
+
+// Uint8 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as a uint8 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as a uint8 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Uint8(key string, def ...uint8) (uint8, error) {
+	return std.Uint8(key, def...)
+}
+
+// Uint16 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as a uint16 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as a uint16 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Uint16(key string, def ...uint16) (uint16, error) {
+	return std.Uint16(key, def...)
+}
+
+// Uint32 retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed as a uint32 value and returned and the error is nil. If the
+// variable is not present but a default value is supplied, that value
+// will be returned. If the env var could not be parsed as a uint32 value,
+// including values out of range, ParseError will be returned.
+// Otherwise the returned value will be empty and NotFoundError will be
+// returned.
+func Uint32(key string, def ...uint32) (uint32, error) {
+	return std.Uint32(key, def...)
 }
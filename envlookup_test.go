@@ -1,8 +1,10 @@
 package envlookup_test
 
 import (
+	"errors"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -24,6 +26,8 @@ func setVars() {
 	os.Setenv("RECORD_LABELS", "Impulse!,Atlantic,Prestige,Blue Note")
 	os.Setenv("LONGEST_RECORDED_TRACK", "27m32s")
 	os.Setenv("LONGEST_RECORDED_TRACK_FLOAT", "27.32")
+	os.Setenv("ALBUM_TRACK_COUNTS", "4,7,5")
+	os.Setenv("ALBUM_LABELS", "A Love Supreme=Impulse!,Giant Steps=Atlantic")
 }
 
 func unsetVars() {
@@ -34,6 +38,8 @@ func unsetVars() {
 	os.Unsetenv("RECORD_LABELS")
 	os.Unsetenv("LONGEST_RECORDED_TRACK")
 	os.Unsetenv("LONGEST_RECORDED_TRACK_FLOAT")
+	os.Unsetenv("ALBUM_TRACK_COUNTS")
+	os.Unsetenv("ALBUM_LABELS")
 }
 
 func TestEnv(t *testing.T) {
@@ -380,6 +386,15 @@ func TestBoolStrParse(t *testing.T) {
 	if val {
 		t.Error("value should be false", val)
 	}
+	if err != nil {
+		t.Error("error should be nil", err)
+	}
+
+	os.Setenv("PLAYED_WITH_MILES_DAVIES", "maybe")
+	val, err = envlookup.Bool("PLAYED_WITH_MILES_DAVIES")
+	if val {
+		t.Error("value should be false", val)
+	}
 	if _, ok := err.(*envlookup.ParseError); !ok {
 		t.Error("error should be envlookup.ParseError", err)
 	}
@@ -447,3 +462,53 @@ func TestEmptyUint64EnvOrDef(t *testing.T) {
 		t.Error("error should be nil", err)
 	}
 }
+
+func TestIntegerWidths(t *testing.T) {
+	if v, err := envlookup.Int8("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("int8 value should be set", v, err)
+	}
+	if v, err := envlookup.Int16("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("int16 value should be set", v, err)
+	}
+	if v, err := envlookup.Int32("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("int32 value should be set", v, err)
+	}
+	if v, err := envlookup.Uint("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("uint value should be set", v, err)
+	}
+	if v, err := envlookup.Uint8("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("uint8 value should be set", v, err)
+	}
+	if v, err := envlookup.Uint16("NO_OF_STUDIO_ALBUMS"); v != 51 || err != nil {
+		t.Error("uint16 value should be set", v, err)
+	}
+	if v, err := envlookup.Uint32("NO_OF_STUDIO_ALBUMS", 1); v != 51 || err != nil {
+		t.Error("uint32 value should be set", v, err)
+	}
+	if v, err := envlookup.Uint16("EMPTY_NO_OF_STUDIO_ALBUMS", 443); v != 443 || err != nil {
+		t.Error("default value should be set", v, err)
+	}
+}
+
+func TestIntegerRangeErr(t *testing.T) {
+	os.Setenv("NO_OF_STUDIO_ALBUMS", "300")
+	defer setVars()
+
+	_, err := envlookup.Int8("NO_OF_STUDIO_ALBUMS")
+	if _, ok := err.(*envlookup.ParseError); !ok || !errors.Is(err, strconv.ErrRange) {
+		t.Error("error should be envlookup.ParseError wrapping strconv.ErrRange", err)
+	}
+	_, err = envlookup.Uint8("NO_OF_STUDIO_ALBUMS")
+	if _, ok := err.(*envlookup.ParseError); !ok || !errors.Is(err, strconv.ErrRange) {
+		t.Error("error should be envlookup.ParseError wrapping strconv.ErrRange", err)
+	}
+	if v, err := envlookup.Int16("NO_OF_STUDIO_ALBUMS"); v != 300 || err != nil {
+		t.Error("int16 value should be set", v, err)
+	}
+
+	os.Setenv("NO_OF_STUDIO_ALBUMS", "-1")
+	_, err = envlookup.Uint32("NO_OF_STUDIO_ALBUMS")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
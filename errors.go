@@ -0,0 +1,58 @@
+package envlookup
+
+import "strings"
+
+// Errors is a list of errors collected from several lookups, so that
+// every missing or malformed environment variable can be reported at
+// once instead of failing on the first one. The zero value is an empty
+// list ready to use.
+//
+// Errors implements Unwrap() []error, so errors.Is and errors.As will
+// find any NotFoundError or ParseError it holds. It is intended for
+// use such as
+//
+//	var errs envlookup.Errors
+//	host, err := envlookup.String("HOST")
+//	errs.Add(err)
+//	port, err := envlookup.Int("PORT")
+//	errs.Add(err)
+//	if err := errs.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Errors []error
+
+// Add appends err to the list. A nil err is ignored, and the elements
+// of a nested Errors are added individually.
+func (e *Errors) Add(err error) {
+	if err == nil {
+		return
+	}
+	if errs, ok := err.(Errors); ok {
+		*e = append(*e, errs...)
+		return
+	}
+	*e = append(*e, err)
+}
+
+// Err returns nil if the list is empty and the list itself otherwise.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error returns the messages of all collected errors, separated by
+// newlines.
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the collected errors.
+func (e Errors) Unwrap() []error {
+	return e
+}
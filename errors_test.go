@@ -0,0 +1,53 @@
+package envlookup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestErrors(t *testing.T) {
+	var errs envlookup.Errors
+	if err := errs.Err(); err != nil {
+		t.Error("error should be nil", err)
+	}
+
+	_, err := envlookup.String("JAZZ_ARTIST")
+	errs.Add(err)
+	_, err = envlookup.String("EMPTY_JAZZ_ARTIST")
+	errs.Add(err)
+	_, err = envlookup.Bool("LONGEST_RECORDED_TRACK")
+	errs.Add(err)
+
+	if len(errs) != 2 {
+		t.Fatal("two errors should be collected", errs)
+	}
+	err = errs.Err()
+	var nf *envlookup.NotFoundError
+	if !errors.As(err, &nf) || nf.Var != "EMPTY_JAZZ_ARTIST" {
+		t.Error("error should contain envlookup.NotFoundError", err)
+	}
+	var pe *envlookup.ParseError
+	if !errors.As(err, &pe) || pe.Var != "LONGEST_RECORDED_TRACK" {
+		t.Error("error should contain envlookup.ParseError", err)
+	}
+	want := nf.Error() + "\n" + pe.Error()
+	if err.Error() != want {
+		t.Error("error message should list all errors", err)
+	}
+	if !errors.Is(err, errs[0]) {
+		t.Error("error should match its elements", err)
+	}
+}
+
+func TestErrorsAddNested(t *testing.T) {
+	var inner, outer envlookup.Errors
+	inner.Add(errors.New("first"))
+	inner.Add(errors.New("second"))
+	outer.Add(inner)
+	outer.Add(errors.New("third"))
+	if len(outer) != 3 {
+		t.Error("nested errors should be flattened", outer)
+	}
+}
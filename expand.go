@@ -0,0 +1,156 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReferenceCycle is wrapped by ExpandError if variables reference
+// each other in a cycle.
+var ErrReferenceCycle = errors.New("reference cycle")
+
+// ExpandError indicates that the references in the value of an
+// environment variable could not be expanded, see Expand. Chain holds
+// the names of the variables that were being expanded, starting with
+// Var.
+type ExpandError struct {
+	Var   string
+	Chain []string
+	Err   error
+}
+
+func (e *ExpandError) Error() string {
+	if len(e.Chain) < 2 {
+		return fmt.Sprintf("could not expand environment variable \"%s\": %s", e.Var, e.Err)
+	}
+	return fmt.Sprintf("could not expand environment variable \"%s\": %s: %s", e.Var, strings.Join(e.Chain, " -> "), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ExpandError) Unwrap() error {
+	return e.Err
+}
+
+// Expand enables the expansion of references to other variables in
+// values, such as
+//
+//	DATABASE_URL=postgres://${DB_USER}:${DB_PASS}@${DB_HOST}/app
+//
+// The references ${VAR} and $VAR are replaced by the value of VAR, or
+// the empty string if VAR is not set. ${VAR:-default} is replaced by
+// default if VAR is not set or empty, and ${VAR:?message} results in
+// ExpandError with the message if VAR is not set or empty. $$ stands
+// for a literal $. Referenced variables are looked up without prefix
+// and are expanded themselves; references that form a cycle result in
+// ExpandError wrapping ErrReferenceCycle.
+func Expand() Option {
+	return func(e *Env) {
+		e.expand = true
+	}
+}
+
+// expandValue expands the references in v, the value of the last
+// variable in chain.
+func (e *Env) expandValue(v string, chain []string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(v); i++ {
+		if v[i] != '$' || i+1 == len(v) {
+			b.WriteByte(v[i])
+			continue
+		}
+		switch next := v[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := closingBrace(v, i+2)
+			if end < 0 {
+				return "", &ExpandError{chain[0], chain, errors.New("unterminated reference")}
+			}
+			s, err := e.expandBraced(v[i+2:end], chain)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+			i = end
+		case isNameChar(next, true):
+			end := i + 1
+			for end < len(v) && isNameChar(v[end], false) {
+				end++
+			}
+			s, _, err := e.resolve(v[i+1:end], chain)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(s)
+			i = end - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the brace closing the reference
+// starting at start in v, or -1 if there is none.
+func closingBrace(v string, start int) int {
+	depth := 0
+	for i := start; i < len(v); i++ {
+		switch v[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// expandBraced expands the reference expr given as ${expr}.
+func (e *Env) expandBraced(expr string, chain []string) (string, error) {
+	end := 0
+	for end < len(expr) && isNameChar(expr[end], end == 0) {
+		end++
+	}
+	name, op := expr[:end], expr[end:]
+	if name == "" || (op != "" && !strings.HasPrefix(op, ":-") && !strings.HasPrefix(op, ":?")) {
+		return "", &ExpandError{chain[0], chain, fmt.Errorf("invalid reference \"${%s}\"", expr)}
+	}
+
+	s, set, err := e.resolve(name, chain)
+	if err != nil || (set && s != "") || op == "" {
+		return s, err
+	}
+	word := op[2:]
+	if op[1] == '?' {
+		if word == "" {
+			word = fmt.Sprintf("\"%s\" is not set or empty", name)
+		}
+		return "", &ExpandError{chain[0], append(chain[:len(chain):len(chain)], name), errors.New(word)}
+	}
+	return e.expandValue(word, chain)
+}
+
+// resolve returns the expanded value of the variable named by name,
+// referenced by the last variable in chain, and whether it is set.
+func (e *Env) resolve(name string, chain []string) (string, bool, error) {
+	chain = append(chain[:len(chain):len(chain)], name)
+	for _, n := range chain[:len(chain)-1] {
+		if n == name {
+			return "", false, &ExpandError{chain[0], chain, ErrReferenceCycle}
+		}
+	}
+	v, err := e.lookupRaw(name)
+	if err != nil {
+		if missing(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	v, err = e.expandValue(v, chain)
+	return v, true, err
+}
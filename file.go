@@ -0,0 +1,88 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileSuffix is appended to the name of a variable to get the name of
+// the variable holding the file to read its value from.
+const fileSuffix = "_FILE"
+
+// ErrFileConflict is wrapped by FileError if both a variable and its
+// _FILE variable are set.
+var ErrFileConflict = errors.New("variable is set both directly and by file")
+
+// FileError indicates that the value of an environment variable could
+// not be read from the file named by its _FILE variable.
+type FileError struct {
+	Var      string
+	Filename string
+	Err      error
+}
+
+func (e *FileError) Error() string {
+	if errors.Is(e.Err, ErrFileConflict) {
+		return fmt.Sprintf("environment variables \"%s\" and \"%s\" are both set", e.Var, e.Var+fileSuffix)
+	}
+	return fmt.Sprintf("could not read environment variable \"%s\" from file \"%s\": %s", e.Var, e.Filename, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// FileFallback enables reading values from files. If a variable such
+// as DB_PASSWORD is not set but DB_PASSWORD_FILE is, the value is read
+// from the file named by DB_PASSWORD_FILE, as with Docker and
+// Kubernetes secrets. A single trailing newline is trimmed from the
+// file contents. If both variables are set, or the file cannot be
+// read, FileError is returned.
+func FileFallback() Option {
+	return func(e *Env) {
+		e.fileFallback = true
+	}
+}
+
+// NoFileFallback disables reading values from files, which is the
+// default. It undoes FileFallback, such as one set with Configure.
+func NoFileFallback() Option {
+	return func(e *Env) {
+		e.fileFallback = false
+	}
+}
+
+// readFile reads the value of the variable named by name from the file
+// named by its _FILE variable, if that is set and file fallback is
+// enabled. set reports whether the variable itself is set.
+func (e *Env) readFile(name string, set bool) (string, bool, error) {
+	if !e.fileFallback {
+		return "", false, nil
+	}
+	filename, ok := e.srcLookup(name + fileSuffix)
+	if !ok {
+		return "", false, nil
+	}
+	if set {
+		return "", true, &FileError{name, filename, ErrFileConflict}
+	}
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return "", true, &FileError{name, filename, err}
+	}
+	v := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(v, "\r"), true, nil
+}
+
+// missing reports whether err, returned by lookup, means that there is
+// no value, so that defaults apply.
+func missing(err error) bool {
+	switch err.(type) {
+	case *NotFoundError, *EmptyError:
+		return true
+	}
+	return false
+}
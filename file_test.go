@@ -0,0 +1,103 @@
+package envlookup_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func writeSecret(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestFileFallback(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"DB_PASSWORD_FILE": writeSecret(t, "hunter2\n"),
+		"PORT_FILE":        writeSecret(t, "5432\r\n"),
+		"EMPTY_FILE":       writeSecret(t, ""),
+		"MISSING_FILE":     filepath.Join(t.TempDir(), "missing"),
+		"DB_HOST":          "db.example.com",
+		"DB_HOST_FILE":     writeSecret(t, "other.example.com"),
+	})
+
+	_, err := env.String("DB_PASSWORD")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("files should not be read by default", err)
+	}
+
+	env = env.With(envlookup.FileFallback())
+	if s, err := env.Secret("DB_PASSWORD"); s.Value() != "hunter2" || err != nil {
+		t.Error("value should be read from the file", err)
+	}
+	if port, err := env.Int("PORT"); port != 5432 || err != nil {
+		t.Error("value should be read from the file", port, err)
+	}
+
+	_, err = env.String("DB_HOST")
+	var fileErr *envlookup.FileError
+	if !errors.As(err, &fileErr) || !errors.Is(err, envlookup.ErrFileConflict) || fileErr.Var != "DB_HOST" {
+		t.Error("error should be envlookup.FileError for the conflict", err)
+	}
+
+	_, err = env.String("MISSING", "default")
+	if !errors.As(err, &fileErr) || !errors.Is(err, os.ErrNotExist) {
+		t.Error("error should be envlookup.FileError for the missing file", err)
+	}
+
+	s, err := env.With(envlookup.EmptyAsUnset()).String("EMPTY", "default")
+	if s != "default" || err != nil {
+		t.Error("empty file should be treated as unset", s, err)
+	}
+
+	env = env.With(envlookup.NoFileFallback())
+	_, err = env.String("DB_PASSWORD")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+	if s, err := env.String("DB_HOST"); s != "db.example.com" || err != nil {
+		t.Error("value should be returned", s, err)
+	}
+}
+
+func TestLoadFileFallback(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"DB_PASSWORD_FILE": writeSecret(t, "hunter2\n"),
+		"PORT_FILE":        filepath.Join(t.TempDir(), "missing"),
+	})
+	var v struct {
+		Password envlookup.SecretString `env:"DB_PASSWORD" file:"true"`
+		NoFile   string                 `env:"DB_PASSWORD" default:"none"`
+	}
+	if err := env.Load(&v); v.Password.Value() != "hunter2" || v.NoFile != "none" || err != nil {
+		t.Error("fields should be loaded", v.NoFile, err)
+	}
+
+	v.NoFile = ""
+	env = env.With(envlookup.FileFallback())
+	if err := env.Load(&v); v.NoFile != "hunter2" || err != nil {
+		t.Error("fields should be loaded", v.NoFile, err)
+	}
+
+	var off struct {
+		Password string `env:"DB_PASSWORD" nofile:"true" default:"none"`
+	}
+	if err := env.Load(&off); off.Password != "none" || err != nil {
+		t.Error("fields should be loaded", off.Password, err)
+	}
+
+	var bad struct {
+		Port int `env:"PORT" default:"8080"`
+	}
+	var fileErr *envlookup.FileError
+	if err := env.Load(&bad); !errors.As(err, &fileErr) {
+		t.Error("error should be envlookup.FileError", err)
+	}
+}
@@ -0,0 +1,52 @@
+package envlookup
+
+import "reflect"
+
+// Get retrieves the value of the environment variable named by the
+// key, parsed as type T. If the variable is present in the environment
+// the parsed value is returned and the error is nil. If the variable
+// is not present but a default value is supplied, that value will be
+// returned. If the env var could not be parsed as a T value,
+// ParseError will be returned. Otherwise the returned value will be
+// empty and NotFoundError will be returned.
+//
+// T may be any type returned by the getters of this package, such as
+// string, every integer width, float64, bool, time.Duration,
+// time.Time, ByteSize, SecretString or the net and net/url types. T
+// may also be a type with a predeclared type as underlying type, a
+// type registered with RegisterParser or RegisterEnum, a type
+// implementing encoding.TextUnmarshaler or flag.Value, or a slice or
+// map of any supported types. For other types UnsupportedTypeError is
+// returned.
+func Get[T any](key string, def ...T) (T, error) {
+	return GetFrom(std, key, def...)
+}
+
+// GetFrom is like Get but retrieves the variable from the source of e.
+func GetFrom[T any](e *Env, key string, def ...T) (T, error) {
+	var res T
+	t := reflect.TypeOf(&res).Elem()
+	e = e.forType(t)
+	parse, ok := e.parserFor(t)
+	if !ok {
+		return res, &UnsupportedTypeError{Type: t}
+	}
+
+	key = e.varName(key)
+	v, err := e.lookup(key)
+	if err != nil {
+		if len(def) > 0 && missing(err) {
+			return def[0], nil
+		}
+		return res, err
+	}
+
+	pv, err := parse(v)
+	if err != nil {
+		return res, &ParseError{key, e.redactError(err)}
+	}
+	if err := e.validate(key, v, pv); err != nil {
+		return res, err
+	}
+	return pv.Interface().(T), nil
+}
@@ -0,0 +1,92 @@
+package envlookup_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestGet(t *testing.T) {
+	s, err := envlookup.Get[string]("JAZZ_ARTIST")
+	if s != "John Coltrane" || err != nil {
+		t.Error("string value should be set", s, err)
+	}
+	b, err := envlookup.Get[bool]("PLAYED_WITH_MILES_DAVIES")
+	if !b || err != nil {
+		t.Error("bool value should be set", b, err)
+	}
+	i, err := envlookup.Get[int]("NO_OF_STUDIO_ALBUMS")
+	if i != 51 || err != nil {
+		t.Error("int value should be set", i, err)
+	}
+	u, err := envlookup.Get[uint64]("NO_OF_UNSIGNED_STUDIO_ALBUMS")
+	if u != 73 || err != nil {
+		t.Error("uint64 value should be set", u, err)
+	}
+	d, err := envlookup.Get[time.Duration]("LONGEST_RECORDED_TRACK")
+	if d != 27*time.Minute+32*time.Second || err != nil {
+		t.Error("duration value should be set", d, err)
+	}
+	f, err := envlookup.Get[float64]("LONGEST_RECORDED_TRACK_FLOAT")
+	if f != 27.32 || err != nil {
+		t.Error("float64 value should be set", f, err)
+	}
+	l, err := envlookup.Get[[]string]("RECORD_LABELS")
+	if len(l) != 4 || err != nil {
+		t.Error("slice value should be set", l, err)
+	}
+}
+
+func TestGetNamedType(t *testing.T) {
+	type labels []string
+	l, err := envlookup.Get[labels]("RECORD_LABELS")
+	want := labels{"Impulse!", "Atlantic", "Prestige", "Blue Note"}
+	if !reflect.DeepEqual(l, want) || err != nil {
+		t.Error("named slice value should be set", l, err)
+	}
+
+	type artist string
+	a, err := envlookup.Get[artist]("JAZZ_ARTIST")
+	if a != "John Coltrane" || err != nil {
+		t.Error("named string value should be set", a, err)
+	}
+}
+
+func TestGetWithDef(t *testing.T) {
+	i, err := envlookup.Get("EMPTY_NO_OF_STUDIO_ALBUMS", 7)
+	if i != 7 || err != nil {
+		t.Error("default value should be set", i, err)
+	}
+
+	i, err = envlookup.Get[int]("EMPTY_NO_OF_STUDIO_ALBUMS")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", i, err)
+	}
+}
+
+func TestGetParseErr(t *testing.T) {
+	i, err := envlookup.Get("LONGEST_RECORDED_TRACK", 7)
+	if i != 0 {
+		t.Error("zero should be set", i)
+	}
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestGetUnsupportedType(t *testing.T) {
+	_, err := envlookup.Get[complex128]("JAZZ_ARTIST")
+	if _, ok := err.(*envlookup.UnsupportedTypeError); !ok {
+		t.Error("error should be envlookup.UnsupportedTypeError", err)
+	}
+}
+
+func TestGetFrom(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"NO_OF_STUDIO_ALBUMS": "48"})
+	i, err := envlookup.GetFrom[int](env, "NO_OF_STUDIO_ALBUMS")
+	if i != 48 || err != nil {
+		t.Error("value should be read from the source", i, err)
+	}
+}
@@ -1 +1,3 @@
 module github.com/spider-pigs/envlookup
+
+go 1.20
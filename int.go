@@ -0,0 +1,119 @@
+package envlookup
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Base sets the base that integer values are parsed in, for every
+// integer type.
+//
+// The default base 0 auto-detects the base from the prefix of the
+// value: 0x for hexadecimal, 0o for octal and 0b for binary (in either
+// case). As with base 0 of strconv.ParseInt, underscores may separate
+// digits. Unlike strconv, values without a prefix are decimal, even
+// with leading zeros, so that "010" is 10 and not 8. This keeps Int
+// reading such values as before, but Uint64, which used to read them
+// as octal, now reads them as decimal too; use the 0o prefix for octal
+// values. Base 10 accepts decimal values only, and base 16 hexadecimal
+// values with an optional 0x prefix. Other bases from 2 to 36 are
+// passed on to strconv.
+func Base(base int) Option {
+	return func(e *Env) {
+		e.base = base
+	}
+}
+
+// Underscores allows underscores as digit separators in integer
+// values, such as 1_000_000, with a base set by Base. They are always
+// allowed with the default base 0. An underscore must separate two
+// digits, or follow a base prefix.
+func Underscores() Option {
+	return func(e *Env) {
+		e.underscores = true
+	}
+}
+
+// intParser returns a parser of signed integers that fit into
+// bitSize bits. Values out of range result in an error wrapping
+// strconv.ErrRange.
+func intParser(bitSize int) parseFunc {
+	return func(e *Env, v string) (any, error) {
+		sign, digits := "", v
+		if strings.HasPrefix(v, "-") || strings.HasPrefix(v, "+") {
+			sign, digits = v[:1], v[1:]
+		}
+		digits, base, err := e.intDigits(digits)
+		if err != nil {
+			return nil, &strconv.NumError{Func: "ParseInt", Num: v, Err: err}
+		}
+		i, err := strconv.ParseInt(sign+digits, base, bitSize)
+		return i, numError(err, v)
+	}
+}
+
+// uintParser returns a parser of unsigned integers that fit into
+// bitSize bits. Values out of range result in an error wrapping
+// strconv.ErrRange.
+func uintParser(bitSize int) parseFunc {
+	return func(e *Env, v string) (any, error) {
+		digits, base, err := e.intDigits(v)
+		if err != nil {
+			return nil, &strconv.NumError{Func: "ParseUint", Num: v, Err: err}
+		}
+		u, err := strconv.ParseUint(digits, base, bitSize)
+		return u, numError(err, v)
+	}
+}
+
+// intDigits strips the base prefix and digit separators allowed by the
+// options of e from the unsigned integer v, and returns the remaining
+// digits and their base.
+func (e *Env) intDigits(v string) (string, int, error) {
+	base := e.base
+	prefix := ""
+	if len(v) > 2 && v[0] == '0' {
+		prefix = strings.ToLower(v[:2])
+	}
+	switch {
+	case base == 0 && prefix == "0x", base == 16 && prefix == "0x":
+		v, base = v[2:], 16
+	case base == 0 && prefix == "0o":
+		v, base = v[2:], 8
+	case base == 0 && prefix == "0b":
+		v, base = v[2:], 2
+	case base == 0:
+		base = 10
+	}
+	// The sign goes before the prefix, so 0x-10 is not valid.
+	if d := strings.TrimPrefix(v, "_"); strings.HasPrefix(d, "-") || strings.HasPrefix(d, "+") {
+		return "", 0, strconv.ErrSyntax
+	}
+
+	if !strings.Contains(v, "_") {
+		return v, base, nil
+	}
+	if !e.underscores && e.base != 0 {
+		return "", 0, strconv.ErrSyntax
+	}
+	// An underscore right after a prefix is fine, as in 0x_ff.
+	if prefix != "" && base != 10 && v[0] == '_' {
+		v = v[1:]
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] == '_' && (i == 0 || i == len(v)-1 || v[i-1] == '_') {
+			return "", 0, errors.New("invalid digit separator")
+		}
+	}
+	return strings.ReplaceAll(v, "_", ""), base, nil
+}
+
+// numError reports err, if it is a *strconv.NumError, as an error
+// parsing the original value v rather than the stripped digits.
+func numError(err error, v string) error {
+	if ne, ok := err.(*strconv.NumError); ok {
+		ne.Num = v
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package envlookup_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var intEnv = envlookup.New(envlookup.MapSource{
+	"DECIMAL":      "42",
+	"LEADING":      "010",
+	"NEGATIVE":     "-42",
+	"HEX":          "0x2A",
+	"NEGATIVE_HEX": "-0x2a",
+	"OCTAL":        "0o52",
+	"BINARY":       "0b101010",
+	"BARE_HEX":     "2a",
+	"UNDERSCORES":  "1_000_000",
+	"HEX_UNDER":    "0x_ff_ff",
+	"BAD_UNDER":    "1__000",
+})
+
+func TestBaseAuto(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int64
+	}{
+		{"DECIMAL", 42},
+		{"LEADING", 10},
+		{"NEGATIVE", -42},
+		{"HEX", 42},
+		{"NEGATIVE_HEX", -42},
+		{"OCTAL", 42},
+		{"BINARY", 42},
+	}
+	for _, test := range tests {
+		i, err := intEnv.Int(test.key)
+		if int64(i) != test.want || err != nil {
+			t.Error("int value should be parsed", test.key, i, err)
+		}
+		i64, err := intEnv.Int64(test.key)
+		if i64 != test.want || err != nil {
+			t.Error("int64 value should be parsed", test.key, i64, err)
+		}
+		if test.want < 0 {
+			continue
+		}
+		u, err := intEnv.Uint64(test.key)
+		if int64(u) != test.want || err != nil {
+			t.Error("uint64 value should be parsed", test.key, u, err)
+		}
+	}
+}
+
+func TestBaseDecimal(t *testing.T) {
+	env := intEnv.With(envlookup.Base(10))
+	if i, err := env.Int("LEADING"); i != 10 || err != nil {
+		t.Error("decimal value should be parsed", i, err)
+	}
+	_, err := env.Int("HEX")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestBaseHex(t *testing.T) {
+	env := intEnv.With(envlookup.Base(16))
+	if u, err := env.Uint8("HEX"); u != 42 || err != nil {
+		t.Error("hex value with prefix should be parsed", u, err)
+	}
+	if u, err := env.Uint8("BARE_HEX"); u != 42 || err != nil {
+		t.Error("hex value without prefix should be parsed", u, err)
+	}
+	if i, err := env.Int("NEGATIVE_HEX"); i != -42 || err != nil {
+		t.Error("negative hex value should be parsed", i, err)
+	}
+}
+
+func TestSignAfterPrefix(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"HEX":        "0x-10",
+		"PLUS_HEX":   "0x+10",
+		"BINARY":     "0b-1",
+		"OCTAL":      "0o-7",
+		"UNDERSCORE": "0x_-10",
+	})
+	for _, key := range []string{"HEX", "PLUS_HEX", "BINARY", "OCTAL", "UNDERSCORE"} {
+		i, err := env.Int(key)
+		if _, ok := err.(*envlookup.ParseError); !ok || !errors.Is(err, strconv.ErrSyntax) {
+			t.Error("sign after the prefix should be rejected", key, i, err)
+		}
+	}
+	i, err := env.With(envlookup.Base(16)).Int("HEX")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("sign after the prefix should be rejected in base 16", i, err)
+	}
+	u, err := env.Uint64("PLUS_HEX")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("sign after the prefix should be rejected by Uint64", u, err)
+	}
+}
+
+func TestUnderscores(t *testing.T) {
+	if i, err := intEnv.Int("UNDERSCORES"); i != 1000000 || err != nil {
+		t.Error("underscores should be allowed with base 0", i, err)
+	}
+	_, err := intEnv.With(envlookup.Base(10)).Int("UNDERSCORES")
+	if _, ok := err.(*envlookup.ParseError); !ok || !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("underscores should not be allowed with an explicit base", err)
+	}
+
+	env := intEnv.With(envlookup.Base(10), envlookup.Underscores())
+	if i, err := env.Int("UNDERSCORES"); i != 1000000 || err != nil {
+		t.Error("underscores should be allowed", i, err)
+	}
+	if u, err := intEnv.Uint32("HEX_UNDER"); u != 0xffff || err != nil {
+		t.Error("underscores after a prefix should be allowed", u, err)
+	}
+	_, err = env.Int("BAD_UNDER")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+// Uint64 used strconv.ParseUint with base 0. Values with underscores
+// still parse the same, but leading zeros no longer select octal.
+func TestUint64Compatibility(t *testing.T) {
+	if u, err := intEnv.Uint64("UNDERSCORES"); u != 1000000 || err != nil {
+		t.Error("underscores should be allowed", u, err)
+	}
+	if u, err := intEnv.Uint64("LEADING"); u != 10 || err != nil {
+		t.Error("leading zeros should be decimal", u, err)
+	}
+	if u, err := envlookup.New(envlookup.MapSource{"MODE": "0o755"}).Uint64("MODE"); u != 0755 || err != nil {
+		t.Error("octal value with prefix should be parsed", u, err)
+	}
+}
+
+func TestLoadBaseTags(t *testing.T) {
+	var v struct {
+		Hex   uint8 `env:"BARE_HEX" base:"16"`
+		Large int   `env:"UNDERSCORES" base:"10" underscores:"true"`
+	}
+	if err := intEnv.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Hex != 42 || v.Large != 1000000 {
+		t.Error("tags should configure integer parsing", v)
+	}
+}
+
+func TestLoadInvalidBaseTag(t *testing.T) {
+	var v struct {
+		Word    uint8 `env:"BARE_HEX" base:"hex"`
+		Range   int   `env:"DECIMAL" base:"37"`
+		Missing int   `env:"MISSING_INT" base:"1"`
+	}
+	err := intEnv.Load(&v)
+	errs, ok := err.(envlookup.Errors)
+	if !ok || len(errs) != 3 {
+		t.Fatal("every invalid tag should be reported", err)
+	}
+	for _, err := range errs {
+		var verr *envlookup.ValidationError
+		if !errors.As(err, &verr) || !strings.HasPrefix(verr.Rule, "base=") {
+			t.Error("error should be envlookup.ValidationError for the tag", err)
+		}
+	}
+}
+
+func TestIntErrorValue(t *testing.T) {
+	_, err := intEnv.With(envlookup.Underscores()).Int8("UNDERSCORES")
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) || ne.Num != "1_000_000" || !errors.Is(err, strconv.ErrRange) {
+		t.Error("error should report the original value", err)
+	}
+}
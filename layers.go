@@ -0,0 +1,99 @@
+package envlookup
+
+import (
+	"flag"
+	"strings"
+)
+
+// Layer is a named Source within Layers.
+type Layer struct {
+	Name   string
+	Source Source
+}
+
+// Layers is a Source combining several sources in order of
+// precedence. A key is looked up in each layer in turn, and the value
+// of the first layer having it is returned. It is intended for use
+// such as
+//
+//	layers := envlookup.Layers{
+//		{"flags", envlookup.Flags(flag.CommandLine)},
+//		{"env", envlookup.OS},
+//		{"file", dotenv},
+//		{"defaults", envlookup.MapSource{"PORT": "8080"}},
+//	}
+//	env := envlookup.New(layers)
+type Layers []Layer
+
+// Lookup retrieves the value of the variable named by the key from
+// the first layer having it. An Env with the EmptyAsUnset option
+// skips layers where the variable is empty instead.
+func (l Layers) Lookup(key string) (string, bool) {
+	v, _, ok := l.lookup(key, false)
+	return v, ok
+}
+
+// Origin returns the name of the layer supplying the value of the
+// variable named by the key. If no layer has the variable, the
+// boolean will be false. Use the Origin method of Env to take its
+// options, such as EmptyAsUnset, into account.
+func (l Layers) Origin(key string) (string, bool) {
+	_, name, ok := l.lookup(key, false)
+	return name, ok
+}
+
+// Origin returns the name of the layer of the source of e supplying
+// the value of the variable named by the key, taking the prefix and
+// the EmptyAsUnset option of e into account. If the source of e is
+// not Layers or no layer has the variable, the boolean will be false.
+func (e *Env) Origin(key string) (string, bool) {
+	l, ok := e.source().(Layers)
+	if !ok {
+		return "", false
+	}
+	_, name, ok := l.lookup(e.varName(key), e.emptyAsUnset)
+	return name, ok
+}
+
+// lookup returns the value of the variable named by the key and the
+// name of the layer having it. If skipEmpty is set, layers where the
+// variable is empty are skipped, unless it is empty in every layer
+// having it.
+func (l Layers) lookup(key string, skipEmpty bool) (string, string, bool) {
+	name, found := "", false
+	for _, layer := range l {
+		v, ok := layer.Source.Lookup(key)
+		if !ok {
+			continue
+		}
+		if v != "" || !skipEmpty {
+			return v, layer.Name, true
+		}
+		if !found {
+			name, found = layer.Name, true
+		}
+	}
+	return "", name, found
+}
+
+// Flags returns a Source with the values of the flags that have been
+// set on the command line of fs, which must already be parsed. Flags
+// left at their default value are not present in the source, so that
+// lower layers of Layers can supply the value.
+//
+// Flag names are mapped to variable names by converting them to upper
+// case and replacing dashes and dots with underscores, so that the
+// flag -db-host supplies the variable DB_HOST.
+func Flags(fs *flag.FlagSet) Source {
+	m := MapSource{}
+	fs.Visit(func(f *flag.Flag) {
+		m[FlagKey(f.Name)] = f.Value.String()
+	})
+	return m
+}
+
+// FlagKey returns the variable name that the flag name is mapped to
+// by Flags.
+func FlagKey(name string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
@@ -0,0 +1,97 @@
+package envlookup_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestLayers(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("jazz-artist", "", "")
+	fs.Int("no-of-studio-albums", 1, "")
+	if err := fs.Parse([]string{"-jazz-artist", "Ornette Coleman"}); err != nil {
+		t.Fatal(err)
+	}
+
+	layers := envlookup.Layers{
+		{"flags", envlookup.Flags(fs)},
+		{"env", envlookup.OS},
+		{"file", envlookup.MapSource{"NO_OF_STUDIO_ALBUMS": "12", "ALBUM": "Giant Steps"}},
+		{"defaults", envlookup.MapSource{"ALBUM": "Blue Train", "LABEL": "Atlantic"}},
+	}
+	env := envlookup.New(layers)
+
+	tests := []struct {
+		key, value, origin string
+	}{
+		{"JAZZ_ARTIST", "Ornette Coleman", "flags"},
+		{"NO_OF_STUDIO_ALBUMS", "51", "env"},
+		{"ALBUM", "Giant Steps", "file"},
+		{"LABEL", "Atlantic", "defaults"},
+	}
+	for _, test := range tests {
+		v, err := env.String(test.key)
+		if v != test.value || err != nil {
+			t.Error("value should be taken from the first layer having it", test.key, v, err)
+		}
+		origin, ok := layers.Origin(test.key)
+		if origin != test.origin || !ok {
+			t.Error("origin should be reported", test.key, origin, ok)
+		}
+	}
+
+	if _, err := env.String("EMPTY_JAZZ_ARTIST"); err == nil {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+	if _, ok := layers.Origin("EMPTY_JAZZ_ARTIST"); ok {
+		t.Error("origin should not be found")
+	}
+}
+
+func TestLayersEmptyAsUnset(t *testing.T) {
+	layers := envlookup.Layers{
+		{"env", envlookup.MapSource{"A": "", "B": ""}},
+		{"file", envlookup.MapSource{"A": "1"}},
+		{"defaults", envlookup.MapSource{"B": ""}},
+	}
+
+	env := envlookup.New(layers)
+	if v, err := env.String("A"); v != "" || err != nil {
+		t.Error("empty value should hide lower layers by default", v, err)
+	}
+
+	env = env.With(envlookup.EmptyAsUnset())
+	if v, err := env.Int("A", 2); v != 1 || err != nil {
+		t.Error("empty layer should be skipped", v, err)
+	}
+	if origin, ok := env.Origin("A"); origin != "file" || !ok {
+		t.Error("origin should be the first non-empty layer", origin, ok)
+	}
+	if origin, ok := layers.Origin("A"); origin != "env" || !ok {
+		t.Error("origin of Layers should not know about the option", origin, ok)
+	}
+
+	_, err := env.String("B")
+	if err, ok := err.(*envlookup.EmptyError); !ok || err.Var != "B" {
+		t.Error("error should be envlookup.EmptyError", err)
+	}
+	if origin, ok := env.Origin("B"); origin != "env" || !ok {
+		t.Error("origin should be the first layer having the empty value", origin, ok)
+	}
+
+	prefixed := env.WithPrefix("PRE_")
+	if _, ok := prefixed.Origin("A"); ok {
+		t.Error("origin should take the prefix into account")
+	}
+	if _, ok := envlookup.New(envlookup.MapSource{"A": "1"}).Origin("A"); ok {
+		t.Error("origin should not be found without Layers")
+	}
+}
+
+func TestFlagKey(t *testing.T) {
+	if k := envlookup.FlagKey("db-host.name"); k != "DB_HOST_NAME" {
+		t.Error("flag name should be mapped", k)
+	}
+}
@@ -0,0 +1,317 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// InvalidLoadError indicates that an invalid argument was passed to
+// Load. The argument must be a non-nil pointer to a struct.
+type InvalidLoadError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidLoadError) Error() string {
+	if e.Type == nil {
+		return "envlookup: Load(nil)"
+	}
+	return fmt.Sprintf("envlookup: Load(non-pointer to struct %s)", e.Type)
+}
+
+// UnsupportedTypeError indicates that a type can not be parsed from
+// an environment variable. Field is the name of the struct field
+// having the type, if any.
+type UnsupportedTypeError struct {
+	Field string
+	Type  reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("envlookup: unsupported type %s", e.Type)
+	}
+	return fmt.Sprintf("envlookup: unsupported type %s for field \"%s\"", e.Type, e.Field)
+}
+
+// Load populates the struct pointed to by v from environment
+// variables. Each exported field tagged with `env:"NAME"` is set from
+// the environment variable NAME, using the same parsing rules as the
+// String, Slice, Int, Int64, Bool, Duration, Float64 and Uint64
+// functions. Types with these underlying kinds, types registered with
+// RegisterParser and types implementing encoding.TextUnmarshaler or
+// flag.Value are supported as well, as are pointers to them.
+//
+// If the variable is not present in the environment, the value of the
+// `default:"..."` tag is parsed and used instead. If there is no
+// default and the field is tagged with `required:"true"`, NotFoundError
+// will be returned. Otherwise the field is left untouched.
+//
+// Nested structs, pointers to structs and embedded structs without an
+// env tag are loaded recursively. Nil struct pointers are allocated.
+// Pointers to a struct type that encloses them, such as the next node
+// of a linked list, are left alone. A `prefix:"..."` tag on such a
+// field is prepended to the keys of the nested fields, so the same
+// struct type can be reused for several components. A field tagged
+// with `env:"-"` is ignored.
+//
+// Slices are split according to the `sep:"..."`, `trim:"true"`,
+// `dropempty:"true"` and `quoted:"true"` tags, which correspond to the
+// Separator, TrimSpace, DropEmpty and Quoted options. Maps are split
+// according to the `pairsep:"..."` and `kvsep:"..."` tags, which
+// correspond to the PairSeparator and KeyValueSeparator options.
+// Integers are parsed according to the `base:"..."` and
+// `underscores:"true"` tags, which correspond to the Base and
+// Underscores options. Durations without unit are read in the unit of
+// the `unit:"..."` tag, such as `unit:"ms"`, as with the DurationUnit
+// option. Times are parsed with the layout of the `layout:"..."` tag,
+// as with the TimeLayout option. Bools tagged with `strictbool:"true"`
+// accept true and false only, as with the StrictBool option. Fields
+// tagged with `emptyasunset:"true"` treat an empty variable as unset,
+// as with the EmptyAsUnset option.
+//
+// Fields tagged with `enum:"name=value,..."`, such as
+// `enum:"debug=-4,info=0"`, map variables to the listed values as by
+// Enum; the values are parsed as the type of the field. With the
+// `ignorecase:"true"` tag the names are matched ignoring case, as with
+// the IgnoreCase option.
+//
+// Fields tagged with `file:"true"` are read from the file named by
+// their _FILE variable if they are not set, as with the FileFallback
+// option. Fields tagged with `nofile:"true"` are not, as with the
+// NoFileFallback option.
+//
+// References to other variables in the values of fields tagged with
+// `expand:"true"` are expanded, as with the Expand option.
+//
+// Values of fields tagged with `sensitive:"true"` are redacted from
+// error messages, as with the Sensitive option, and from Dump.
+//
+// Values are validated according to the `min:"..."`, `max:"..."`,
+// `oneof:"..."` (space-separated values), `regex:"..."` and
+// `nonempty:"true"` tags, which correspond to the Min, Max, OneOf,
+// Regex and NonEmpty options. Bounds of duration fields are given as
+// durations, such as `min:"1s"`, or as plain numbers in the unit of the
+// `unit:"..."` tag. Values failing a rule result in a
+// ValidationError. So do tags that cannot be parsed, such as
+// `base:"hex"`, whether or not the variable is set.
+//
+// If a variable or a default could not be parsed, ParseError will be
+// returned. Loading does not stop at the first failing field; all
+// failures are returned together as Errors.
+func Load(v any) error {
+	return std.Load(v)
+}
+
+// Load populates the struct pointed to by v from variables in the
+// source of e. See the package-level Load for details.
+func (e *Env) Load(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return &InvalidLoadError{reflect.TypeOf(v)}
+	}
+	var errs Errors
+	e.loadStruct(rv.Elem(), nil, &errs)
+	return errs.Err()
+}
+
+// loadStruct loads the fields of rv. path holds the struct types being
+// loaded, from the outermost down to the one containing rv.
+func (e *Env) loadStruct(rv reflect.Value, path []reflect.Type, errs *Errors) {
+	rt := rv.Type()
+	path = append(path[:len(path):len(path)], rt)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+
+		key, tagged := sf.Tag.Lookup("env")
+		if key == "-" {
+			continue
+		}
+		if !tagged {
+			e.loadNested(sf, fv, path, errs)
+			continue
+		}
+		if !fv.CanSet() {
+			continue
+		}
+		errs.Add(e.loadField(sf, fv, key))
+	}
+}
+
+// loadNested descends into untagged struct and struct pointer fields.
+// Pointers to a struct type that is already being loaded, as in a
+// linked list, are skipped, since they would be loaded forever.
+func (e *Env) loadNested(sf reflect.StructField, fv reflect.Value, path []reflect.Type, errs *Errors) {
+	if prefix, ok := sf.Tag.Lookup("prefix"); ok {
+		e = e.WithPrefix(prefix)
+	}
+	switch {
+	case fv.Kind() == reflect.Struct:
+		// Embedded structs of unexported types may still have
+		// exported fields that can be set.
+		if sf.PkgPath != "" && !sf.Anonymous {
+			return
+		}
+		e.loadStruct(fv, path, errs)
+	case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct:
+		if !fv.CanSet() {
+			return
+		}
+		if inPath(path, fv.Type().Elem()) {
+			return
+		}
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		e.loadStruct(fv.Elem(), path, errs)
+	}
+}
+
+// inPath reports whether the struct type t is in path.
+func inPath(path []reflect.Type, t reflect.Type) bool {
+	for _, p := range path {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Env) loadField(sf reflect.StructField, fv reflect.Value, key string) error {
+	e = e.With(fieldOptions(sf)...).forType(fv.Type())
+
+	// Pointer fields are set to a pointer to the parsed value, unless
+	// there is a parser for the pointer type itself.
+	t := fv.Type()
+	parse, ok := e.parserFor(t)
+	if !ok && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		parse, ok = e.parserFor(t)
+	}
+	if !ok {
+		return &UnsupportedTypeError{sf.Name, fv.Type()}
+	}
+
+	if tag, ok := sf.Tag.Lookup("enum"); ok {
+		enum, err := e.enumTagParser(tag, parse)
+		if err != nil {
+			e = e.With(invalidTag("enum", tag, err))
+		} else {
+			parse = enum
+		}
+	}
+
+	key = e.varName(key)
+	if err := e.checkTags(key); err != nil {
+		return err
+	}
+
+	v, err := e.lookup(key)
+	if err != nil {
+		if !missing(err) {
+			return err
+		}
+		def, ok := sf.Tag.Lookup("default")
+		if !ok {
+			if tagBool(sf, "required") {
+				return err
+			}
+			return nil
+		}
+		v = def
+	}
+
+	pv, err := parse(v)
+	if err != nil {
+		return &ParseError{key, e.redactError(err)}
+	}
+	if err := e.validate(key, v, pv); err != nil {
+		return err
+	}
+	if t != fv.Type() {
+		p := reflect.New(t)
+		p.Elem().Set(pv)
+		pv = p
+	}
+	fv.Set(pv)
+	return nil
+}
+
+// fieldOptions returns the options set by the tags of a struct field.
+func fieldOptions(sf reflect.StructField) []Option {
+	var opts []Option
+	if sep, ok := sf.Tag.Lookup("sep"); ok {
+		opts = append(opts, Separator(sep))
+	}
+	if tagBool(sf, "trim") {
+		opts = append(opts, TrimSpace())
+	}
+	if tagBool(sf, "dropempty") {
+		opts = append(opts, DropEmpty())
+	}
+	if tagBool(sf, "quoted") {
+		opts = append(opts, Quoted())
+	}
+	if sep, ok := sf.Tag.Lookup("pairsep"); ok {
+		opts = append(opts, PairSeparator(sep))
+	}
+	if sep, ok := sf.Tag.Lookup("kvsep"); ok {
+		opts = append(opts, KeyValueSeparator(sep))
+	}
+	if s, ok := sf.Tag.Lookup("base"); ok {
+		base, err := strconv.Atoi(s)
+		if err == nil && (base == 1 || base < 0 || base > 36) {
+			err = errors.New("base must be 0 or between 2 and 36")
+		}
+		if err != nil {
+			opts = append(opts, invalidTag("base", s, err))
+		} else {
+			opts = append(opts, Base(base))
+		}
+	}
+	if tagBool(sf, "underscores") {
+		opts = append(opts, Underscores())
+	}
+	if s, ok := sf.Tag.Lookup("unit"); ok {
+		unit, err := time.ParseDuration("1" + s)
+		if err != nil {
+			opts = append(opts, invalidTag("unit", s, err))
+		} else {
+			opts = append(opts, DurationUnit(unit))
+		}
+	}
+	if layout, ok := sf.Tag.Lookup("layout"); ok {
+		opts = append(opts, TimeLayout(layout))
+	}
+	if tagBool(sf, "strictbool") {
+		opts = append(opts, StrictBool())
+	}
+	if tagBool(sf, "ignorecase") {
+		opts = append(opts, IgnoreCase())
+	}
+	if tagBool(sf, "file") {
+		opts = append(opts, FileFallback())
+	}
+	if tagBool(sf, "nofile") {
+		opts = append(opts, NoFileFallback())
+	}
+	if tagBool(sf, "expand") {
+		opts = append(opts, Expand())
+	}
+	if tagBool(sf, "sensitive") {
+		opts = append(opts, Sensitive())
+	}
+	if tagBool(sf, "emptyasunset") {
+		opts = append(opts, EmptyAsUnset())
+	}
+	return append(opts, ruleOptions(sf)...)
+}
+
+// tagBool reports whether the tag of a struct field is set to true.
+func tagBool(sf reflect.StructField, name string) bool {
+	b, _ := strconv.ParseBool(sf.Tag.Get(name))
+	return b
+}
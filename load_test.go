@@ -0,0 +1,167 @@
+package envlookup_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+type album struct {
+	Title  string `env:"ALBUM_TITLE" default:"A Love Supreme"`
+	Tracks int    `env:"ALBUM_TRACKS" default:"4"`
+}
+
+type label string
+
+type artist struct {
+	Name     string        `env:"JAZZ_ARTIST"`
+	Played   bool          `env:"PLAYED_WITH_MILES_DAVIES"`
+	Albums   int           `env:"NO_OF_STUDIO_ALBUMS"`
+	Unsigned uint64        `env:"NO_OF_UNSIGNED_STUDIO_ALBUMS"`
+	Labels   []string      `env:"RECORD_LABELS"`
+	Longest  time.Duration `env:"LONGEST_RECORDED_TRACK"`
+	Float    *float64      `env:"LONGEST_RECORDED_TRACK_FLOAT"`
+	Label    label         `env:"EMPTY_LABEL" default:"Impulse!"`
+	Ignored  string        `env:"-"`
+	Missing  string        `env:"EMPTY_JAZZ_ARTIST"`
+	Favorite album
+	Latest   *album
+}
+
+func TestLoad(t *testing.T) {
+	var a artist
+	a.Missing = "unchanged"
+	if err := envlookup.Load(&a); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if a.Name != "John Coltrane" || !a.Played || a.Albums != 51 || a.Unsigned != 73 {
+		t.Error("values should be set", a)
+	}
+	if !reflect.DeepEqual(a.Labels, []string{"Impulse!", "Atlantic", "Prestige", "Blue Note"}) {
+		t.Error("slice value should be set", a.Labels)
+	}
+	if a.Longest != 27*time.Minute+32*time.Second {
+		t.Error("duration value should be set", a.Longest)
+	}
+	if a.Float == nil || *a.Float != 27.32 {
+		t.Error("pointer value should be set", a.Float)
+	}
+	if a.Label != "Impulse!" {
+		t.Error("default value should be set", a.Label)
+	}
+	if a.Missing != "unchanged" {
+		t.Error("missing value should be left untouched", a.Missing)
+	}
+	if a.Favorite.Title != "A Love Supreme" || a.Favorite.Tracks != 4 {
+		t.Error("nested struct should be loaded", a.Favorite)
+	}
+	if a.Latest == nil || a.Latest.Tracks != 4 {
+		t.Error("nested struct pointer should be allocated and loaded", a.Latest)
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	var v struct {
+		album
+		Name string `env:"JAZZ_ARTIST"`
+	}
+	if err := envlookup.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Title != "A Love Supreme" || v.Name != "John Coltrane" {
+		t.Error("embedded struct should be loaded", v)
+	}
+}
+
+type node struct {
+	Name  string `env:"JAZZ_ARTIST"`
+	Next  *node
+	Group *struct {
+		Parent *node
+	}
+}
+
+func TestLoadSelfReferential(t *testing.T) {
+	var n node
+	if err := envlookup.Load(&n); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if n.Name != "John Coltrane" || n.Next != nil {
+		t.Error("self-referential pointer should be skipped", n)
+	}
+	if n.Group == nil || n.Group.Parent != nil {
+		t.Error("pointer back to an enclosing struct should be skipped", n.Group)
+	}
+}
+
+func TestLoadRequired(t *testing.T) {
+	var v struct {
+		Name string `env:"EMPTY_JAZZ_ARTIST" required:"true"`
+	}
+	err := envlookup.Load(&v)
+	var nf *envlookup.NotFoundError
+	if !errors.As(err, &nf) {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+}
+
+func TestLoadParseErr(t *testing.T) {
+	os.Setenv("NO_OF_STUDIO_ALBUMS", "ABC")
+	defer setVars()
+	var v struct {
+		Albums int `env:"NO_OF_STUDIO_ALBUMS"`
+	}
+	err := envlookup.Load(&v)
+	var pe *envlookup.ParseError
+	if !errors.As(err, &pe) {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	var d struct {
+		Albums int `env:"EMPTY_NO_OF_STUDIO_ALBUMS" default:"many"`
+	}
+	err = envlookup.Load(&d)
+	if !errors.As(err, &pe) {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestLoadAllErrors(t *testing.T) {
+	os.Setenv("NO_OF_STUDIO_ALBUMS", "ABC")
+	defer setVars()
+	var v struct {
+		Name   string `env:"EMPTY_JAZZ_ARTIST" required:"true"`
+		Albums int    `env:"NO_OF_STUDIO_ALBUMS"`
+		Played bool   `env:"LONGEST_RECORDED_TRACK"`
+	}
+	err := envlookup.Load(&v)
+	errs, ok := err.(envlookup.Errors)
+	if !ok || len(errs) != 3 {
+		t.Error("all errors should be returned", err)
+	}
+}
+
+func TestLoadUnsupportedType(t *testing.T) {
+	var v struct {
+		Ch chan int `env:"JAZZ_ARTIST"`
+	}
+	err := envlookup.Load(&v)
+	var ut *envlookup.UnsupportedTypeError
+	if !errors.As(err, &ut) {
+		t.Error("error should be envlookup.UnsupportedTypeError", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	var v artist
+	for _, arg := range []interface{}{nil, v, (*artist)(nil), new(int)} {
+		err := envlookup.Load(arg)
+		if _, ok := err.(*envlookup.InvalidLoadError); !ok {
+			t.Error("error should be envlookup.InvalidLoadError", arg, err)
+		}
+	}
+}
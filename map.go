@@ -0,0 +1,127 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PairError indicates that a key/value pair of a map could not be
+// parsed. Index is the position of the pair in the list and Key its
+// key, if it could be determined. It is wrapped in a ParseError naming
+// the variable.
+type PairError struct {
+	Index int
+	Key   string
+	Err   error
+}
+
+func (e *PairError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("pair %d: %s", e.Index, e.Err)
+	}
+	return fmt.Sprintf("pair %d (key \"%s\"): %s", e.Index, e.Key, e.Err)
+}
+
+func (e *PairError) Unwrap() error {
+	return e.Err
+}
+
+// PairSeparator sets the separator between the key/value pairs of a
+// map. The default separator is ",".
+func PairSeparator(sep string) Option {
+	return func(e *Env) {
+		e.pairSep = sep
+	}
+}
+
+// KeyValueSeparator sets the separator between the key and the value
+// of a pair of a map. The default separator is "=".
+func KeyValueSeparator(sep string) Option {
+	return func(e *Env) {
+		e.kvSep = sep
+	}
+}
+
+// Map retrieves the value of the environment variable named by
+// the key as a map, parsed from a list of key/value pairs such as
+// "K1=v1,K2=v2". An empty value yields an empty map. If the variable
+// is not present but a default value is supplied, that value will be
+// returned. If a pair has no key/value separator or repeats a key,
+// ParseError wrapping a PairError will be returned. Otherwise the
+// returned value will be empty and NotFoundError will be returned.
+//
+// The separators can be configured with the PairSeparator and
+// KeyValueSeparator options. TrimSpace trims keys and values, and
+// DropEmpty skips empty pairs. Maps with other key and value types are
+// supported by Get.
+func Map(key string, def ...map[string]string) (map[string]string, error) {
+	return std.Map(key, def...)
+}
+
+// IntMap retrieves the value of the environment variable named by the
+// key as a map, with each value parsed as by Int. Errors and defaults
+// are handled as by Map.
+func IntMap(key string, def ...map[string]int) (map[string]int, error) {
+	return std.IntMap(key, def...)
+}
+
+// Map retrieves the value of the variable named by the key from
+// the source of e. See the package-level Map for details.
+func (e *Env) Map(key string, def ...map[string]string) (map[string]string, error) {
+	return GetFrom(e, key, def...)
+}
+
+// IntMap retrieves the value of the variable named by the key from the
+// source of e. See the package-level IntMap for details.
+func (e *Env) IntMap(key string, def ...map[string]int) (map[string]int, error) {
+	return GetFrom(e, key, def...)
+}
+
+// mapParser returns a function parsing values into maps of type t, if
+// its key and element types are supported.
+func (e *Env) mapParser(t reflect.Type) (func(v string) (reflect.Value, error), bool) {
+	parseKey, ok := e.parserFor(t.Key())
+	if !ok {
+		return nil, false
+	}
+	parseElem, ok := e.parserFor(t.Elem())
+	if !ok {
+		return nil, false
+	}
+	return func(v string) (reflect.Value, error) {
+		res := reflect.MakeMap(t)
+		if v == "" {
+			return res, nil
+		}
+		seen := make(map[string]bool)
+		for i, pair := range strings.Split(v, e.pairSep) {
+			if e.dropEmpty && strings.TrimSpace(pair) == "" {
+				continue
+			}
+			k, ev, ok := strings.Cut(pair, e.kvSep)
+			if !ok {
+				return reflect.Value{}, &PairError{i, "", fmt.Errorf("missing separator \"%s\"", e.kvSep)}
+			}
+			if e.trim {
+				k, ev = strings.TrimSpace(k), strings.TrimSpace(ev)
+			}
+			if seen[k] {
+				return reflect.Value{}, &PairError{i, e.redactValue(k), errors.New("duplicate key")}
+			}
+			seen[k] = true
+
+			kv, err := parseKey(k)
+			if err != nil {
+				return reflect.Value{}, &PairError{i, e.redactValue(k), e.redactError(err)}
+			}
+			vv, err := parseElem(ev)
+			if err != nil {
+				return reflect.Value{}, &PairError{i, e.redactValue(k), e.redactError(err)}
+			}
+			res.SetMapIndex(kv, vv)
+		}
+		return res, nil
+	}, true
+}
@@ -0,0 +1,102 @@
+package envlookup_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var mapEnv = envlookup.New(envlookup.MapSource{
+	"LABELS":     "artist=coltrane,label=impulse",
+	"TRACKS":     "Acknowledgement = 7, Resolution = 7,,Psalm=7",
+	"LENGTHS":    "Acknowledgement:7m42s;Resolution:7m20s",
+	"EMPTY":      "",
+	"MALFORMED":  "artist=coltrane,impulse",
+	"DUPLICATE":  "artist=coltrane,artist=davis",
+	"BAD_NUMBER": "a=1,b=two",
+})
+
+func TestStringMap(t *testing.T) {
+	m, err := mapEnv.Map("LABELS")
+	want := map[string]string{"artist": "coltrane", "label": "impulse"}
+	if !reflect.DeepEqual(m, want) || err != nil {
+		t.Error("pairs should be parsed", m, err)
+	}
+
+	m, err = mapEnv.Map("EMPTY")
+	if len(m) != 0 || m == nil || err != nil {
+		t.Error("empty value should yield an empty map", m, err)
+	}
+
+	def := map[string]string{"artist": "davis"}
+	m, err = mapEnv.Map("MISSING", def)
+	if !reflect.DeepEqual(m, def) || err != nil {
+		t.Error("default value should be set", m, err)
+	}
+
+	_, err = mapEnv.Map("MISSING")
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+}
+
+func TestIntMap(t *testing.T) {
+	m, err := mapEnv.With(envlookup.TrimSpace(), envlookup.DropEmpty()).IntMap("TRACKS")
+	want := map[string]int{"Acknowledgement": 7, "Resolution": 7, "Psalm": 7}
+	if !reflect.DeepEqual(m, want) || err != nil {
+		t.Error("values should be parsed", m, err)
+	}
+}
+
+func TestMapSeparators(t *testing.T) {
+	env := mapEnv.With(envlookup.PairSeparator(";"), envlookup.KeyValueSeparator(":"))
+	m, err := envlookup.GetFrom[map[string]time.Duration](env, "LENGTHS")
+	want := map[string]time.Duration{
+		"Acknowledgement": 7*time.Minute + 42*time.Second,
+		"Resolution":      7*time.Minute + 20*time.Second,
+	}
+	if !reflect.DeepEqual(m, want) || err != nil {
+		t.Error("pairs should be split on the separators", m, err)
+	}
+}
+
+func TestMapPairErr(t *testing.T) {
+	tests := []struct {
+		key   string
+		index int
+		pair  string
+	}{
+		{"MALFORMED", 1, ""},
+		{"DUPLICATE", 1, "artist"},
+		{"BAD_NUMBER", 1, "b"},
+	}
+	for _, test := range tests {
+		_, err := mapEnv.IntMap(test.key)
+		if test.key != "BAD_NUMBER" {
+			_, err = mapEnv.Map(test.key)
+		}
+		var pe *envlookup.ParseError
+		if !errors.As(err, &pe) || pe.Var != test.key {
+			t.Error("error should be envlookup.ParseError", test.key, err)
+		}
+		var pairErr *envlookup.PairError
+		if !errors.As(err, &pairErr) || pairErr.Index != test.index || pairErr.Key != test.pair {
+			t.Error("error should pinpoint the pair", test.key, err)
+		}
+	}
+}
+
+func TestLoadMapTags(t *testing.T) {
+	var v struct {
+		Lengths map[string]time.Duration `env:"LENGTHS" pairsep:";" kvsep:":"`
+	}
+	if err := mapEnv.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if len(v.Lengths) != 2 {
+		t.Error("tags should configure the separators", v.Lengths)
+	}
+}
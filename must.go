@@ -1,16 +1,36 @@
 package envlookup
 
-import "time"
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// Must is a helper that wraps a call to a function returning (T, error)
+// and panics if the error is non-nil. It is intended for use such as
+//	port := envlookup.Must(envlookup.Get[int]("PORT"))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
 
 // MustBool is a helper that wraps a call to a function returning
 // (bool, error) and panics if the error is non-nil. It is intended for
 // use such as
 //	b := envlookup.MustBool(envlookup.Bool("key"))
 func MustBool(b bool, err error) bool {
-	if err != nil {
-		panic(err)
-	}
-	return b
+	return Must(b, err)
+}
+
+// MustBytes is a helper that wraps a call to a function returning
+// (ByteSize, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	b := envlookup.MustBytes(envlookup.Bytes("key"))
+func MustBytes(b ByteSize, err error) ByteSize {
+	return Must(b, err)
 }
 
 // MustDuration is a helper that wraps a call to a function returning
@@ -18,10 +38,7 @@ func MustBool(b bool, err error) bool {
 // intended for use such as
 //	d := envlookup.MustDuration(envlookup.Duration("key"))
 func MustDuration(d time.Duration, err error) time.Duration {
-	if err != nil {
-		panic(err)
-	}
-	return d
+	return Must(d, err)
 }
 
 // MustFloat64 is a helper that wraps a call to a function returning
@@ -29,10 +46,7 @@ func MustDuration(d time.Duration, err error) time.Duration {
 // for use such as
 //	f := envlookup.MustFloat64(envlookup.Float64("key"))
 func MustFloat64(f float64, err error) float64 {
-	if err != nil {
-		panic(err)
-	}
-	return f
+	return Must(f, err)
 }
 
 // MustInt is a helper that wraps a call to a function returning
@@ -40,10 +54,7 @@ func MustFloat64(f float64, err error) float64 {
 // use such as
 //	i := envlookup.MustInt(envlookup.Int("key"))
 func MustInt(i int, err error) int {
-	if err != nil {
-		panic(err)
-	}
-	return i
+	return Must(i, err)
 }
 
 // MustInt64 is a helper that wraps a call to a function returning
@@ -51,10 +62,71 @@ func MustInt(i int, err error) int {
 // use such as
 //	i := envlookup.MustInt64(envlookup.Int64("key"))
 func MustInt64(i int64, err error) int64 {
-	if err != nil {
-		panic(err)
-	}
-	return i
+	return Must(i, err)
+}
+
+// MustInt8 is a helper that wraps a call to a function returning
+// (int8, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	i := envlookup.MustInt8(envlookup.Int8("key"))
+func MustInt8(i int8, err error) int8 {
+	return Must(i, err)
+}
+
+// MustInt16 is a helper that wraps a call to a function returning
+// (int16, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	i := envlookup.MustInt16(envlookup.Int16("key"))
+func MustInt16(i int16, err error) int16 {
+	return Must(i, err)
+}
+
+// MustInt32 is a helper that wraps a call to a function returning
+// (int32, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	i := envlookup.MustInt32(envlookup.Int32("key"))
+func MustInt32(i int32, err error) int32 {
+	return Must(i, err)
+}
+
+// MustIP is a helper that wraps a call to a function returning
+// (net.IP, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	ip := envlookup.MustIP(envlookup.IP("key"))
+func MustIP(ip net.IP, err error) net.IP {
+	return Must(ip, err)
+}
+
+// MustIPNet is a helper that wraps a call to a function returning
+// (*net.IPNet, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	n := envlookup.MustIPNet(envlookup.IPNet("key"))
+func MustIPNet(n *net.IPNet, err error) *net.IPNet {
+	return Must(n, err)
+}
+
+// MustLocation is a helper that wraps a call to a function returning
+// (*time.Location, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	l := envlookup.MustLocation(envlookup.Location("key"))
+func MustLocation(l *time.Location, err error) *time.Location {
+	return Must(l, err)
+}
+
+// MustPrefix is a helper that wraps a call to a function returning
+// (netip.Prefix, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	p := envlookup.MustPrefix(envlookup.Prefix("key"))
+func MustPrefix(p netip.Prefix, err error) netip.Prefix {
+	return Must(p, err)
+}
+
+// MustSecret is a helper that wraps a call to a function returning
+// (SecretString, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	s := envlookup.MustSecret(envlookup.Secret("key"))
+func MustSecret(s SecretString, err error) SecretString {
+	return Must(s, err)
 }
 
 // MustSlice is a helper that wraps a call to a function returning
@@ -62,10 +134,7 @@ func MustInt64(i int64, err error) int64 {
 // for use such as
 //	s := envlookup.MustSlice(envlookup.Slice("key"))
 func MustSlice(s []string, err error) []string {
-	if err != nil {
-		panic(err)
-	}
-	return s
+	return Must(s, err)
 }
 
 // MustString is a helper that wraps a call to a function returning
@@ -73,10 +142,31 @@ func MustSlice(s []string, err error) []string {
 // for use such as
 //	s := envlookup.MustString(envlookup.String("key"))
 func MustString(s string, err error) string {
-	if err != nil {
-		panic(err)
-	}
-	return s
+	return Must(s, err)
+}
+
+// MustTCPAddr is a helper that wraps a call to a function returning
+// (*net.TCPAddr, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	a := envlookup.MustTCPAddr(envlookup.TCPAddr("key"))
+func MustTCPAddr(a *net.TCPAddr, err error) *net.TCPAddr {
+	return Must(a, err)
+}
+
+// MustTime is a helper that wraps a call to a function returning
+// (time.Time, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	t := envlookup.MustTime(envlookup.Time("key", time.RFC3339))
+func MustTime(t time.Time, err error) time.Time {
+	return Must(t, err)
+}
+
+// MustURL is a helper that wraps a call to a function returning
+// (*url.URL, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	u := envlookup.MustURL(envlookup.URL("key"))
+func MustURL(u *url.URL, err error) *url.URL {
+	return Must(u, err)
 }
 
 // MustUint64 is a helper that wraps a call to a function returning
@@ -84,8 +174,101 @@ func MustString(s string, err error) string {
 // for use such as
 //	u := envlookup.MustUint64(envlookup.Uint64("key"))
 func MustUint64(u uint64, err error) uint64 {
-	if err != nil {
-		panic(err)
-	}
-	return u
+	return Must(u, err)
+}
+
+// MustUint is a helper that wraps a call to a function returning
+// (uint, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	u := envlookup.MustUint(envlookup.Uint("key"))
+func MustUint(u uint, err error) uint {
+	return Must(u, err)
+}
+
+// MustUint8 is a helper that wraps a call to a function returning
+// (uint8, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	u := envlookup.MustUint8(envlookup.Uint8("key"))
+func MustUint8(u uint8, err error) uint8 {
+	return Must(u, err)
+}
+
+// MustUint16 is a helper that wraps a call to a function returning
+// (uint16, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	u := envlookup.MustUint16(envlookup.Uint16("key"))
+func MustUint16(u uint16, err error) uint16 {
+	return Must(u, err)
+}
+
+// MustUint32 is a helper that wraps a call to a function returning
+// (uint32, error) and panics if the error is non-nil. It is intended for
+// use such as
+//	u := envlookup.MustUint32(envlookup.Uint32("key"))
+func MustUint32(u uint32, err error) uint32 {
+	return Must(u, err)
+}
+
+// MustIntSlice is a helper that wraps a call to a function returning
+// ([]int, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	i := envlookup.MustIntSlice(envlookup.IntSlice("key"))
+func MustIntSlice(i []int, err error) []int {
+	return Must(i, err)
+}
+
+// MustInt64Slice is a helper that wraps a call to a function returning
+// ([]int64, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	i := envlookup.MustInt64Slice(envlookup.Int64Slice("key"))
+func MustInt64Slice(i []int64, err error) []int64 {
+	return Must(i, err)
+}
+
+// MustUint64Slice is a helper that wraps a call to a function
+// returning ([]uint64, error) and panics if the error is non-nil. It
+// is intended for use such as
+//	u := envlookup.MustUint64Slice(envlookup.Uint64Slice("key"))
+func MustUint64Slice(u []uint64, err error) []uint64 {
+	return Must(u, err)
+}
+
+// MustFloat64Slice is a helper that wraps a call to a function
+// returning ([]float64, error) and panics if the error is non-nil. It
+// is intended for use such as
+//	f := envlookup.MustFloat64Slice(envlookup.Float64Slice("key"))
+func MustFloat64Slice(f []float64, err error) []float64 {
+	return Must(f, err)
+}
+
+// MustBoolSlice is a helper that wraps a call to a function returning
+// ([]bool, error) and panics if the error is non-nil. It is intended
+// for use such as
+//	b := envlookup.MustBoolSlice(envlookup.BoolSlice("key"))
+func MustBoolSlice(b []bool, err error) []bool {
+	return Must(b, err)
+}
+
+// MustDurationSlice is a helper that wraps a call to a function
+// returning ([]time.Duration, error) and panics if the error is
+// non-nil. It is intended for use such as
+//	d := envlookup.MustDurationSlice(envlookup.DurationSlice("key"))
+func MustDurationSlice(d []time.Duration, err error) []time.Duration {
+	return Must(d, err)
+}
+
+// MustMap is a helper that wraps a call to a function returning
+// (map[string]string, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	m := envlookup.MustMap(envlookup.Map("key"))
+func MustMap(m map[string]string, err error) map[string]string {
+	return Must(m, err)
+}
+
+// MustIntMap is a helper that wraps a call to a function returning
+// (map[string]int, error) and panics if the error is non-nil. It is
+// intended for use such as
+//	m := envlookup.MustIntMap(envlookup.IntMap("key"))
+func MustIntMap(m map[string]int, err error) map[string]int {
+	return Must(m, err)
 }
@@ -6,6 +6,28 @@ import (
 	"github.com/spider-pigs/envlookup"
 )
 
+func TestMust(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+	i := envlookup.Must(envlookup.Get[int]("NO_OF_STUDIO_ALBUMS"))
+	if i == 0 {
+		t.Error("value should not be zero", i)
+	}
+}
+
+func TestMustPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.Must(envlookup.Get[int]("PANIC_PLEASE"))
+}
+
 func TestMustBool(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -188,3 +210,118 @@ func TestMustUint64Panic(t *testing.T) {
 
 	envlookup.MustUint64(envlookup.Uint64("PANIC_PLEASE"))
 }
+
+func TestMustIntSlice(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+
+	i := envlookup.MustIntSlice(envlookup.IntSlice("ALBUM_TRACK_COUNTS"))
+	if len(i) != 3 {
+		t.Error("value should have three elements", i)
+	}
+}
+
+func TestMustIntSlicePanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.MustIntSlice(envlookup.IntSlice("RECORD_LABELS"))
+}
+
+func TestMustMap(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+
+	m := envlookup.MustMap(envlookup.Map("ALBUM_LABELS"))
+	if m["Giant Steps"] != "Atlantic" {
+		t.Error("value should be set", m)
+	}
+}
+
+func TestMustMapPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.MustMap(envlookup.Map("RECORD_LABELS"))
+}
+
+func TestMustUint16(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+
+	u := envlookup.MustUint16(envlookup.Uint16("NO_OF_UNSIGNED_STUDIO_ALBUMS"))
+	if u == 0 {
+		t.Error("value should not be zero", u)
+	}
+}
+
+func TestMustUint16Panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.MustUint16(envlookup.Uint16("PANIC_PLEASE"))
+}
+
+func TestMustBytes(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+
+	b := envlookup.MustBytes(envlookup.Bytes("NO_OF_STUDIO_ALBUMS"))
+	if b != 51 {
+		t.Error("value should be set", b)
+	}
+}
+
+func TestMustBytesPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.MustBytes(envlookup.Bytes("JAZZ_ARTIST"))
+}
+
+func TestMustURL(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Function call should not panic")
+		}
+	}()
+
+	u := envlookup.MustURL(envlookup.New(envlookup.MapSource{"URL": "https://example.com"}).URL("URL"))
+	if u.Host != "example.com" {
+		t.Error("value should be set", u)
+	}
+}
+
+func TestMustIPPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Function call should panic")
+		}
+	}()
+
+	envlookup.MustIP(envlookup.IP("JAZZ_ARTIST"))
+}
@@ -0,0 +1,228 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+)
+
+// hostPort is a string of the form "host:port". It lets HostPort and
+// HostPortSlice share the parsing of GetFrom.
+type hostPort string
+
+// URL retrieves the value of the environment variable named by the
+// key as an absolute URL, parsed by url.Parse. If the variable is
+// present in the environment the parsed URL is returned and the error
+// is nil. If the variable is not present but a default value is
+// supplied, that value will be returned. If the env var could not be
+// parsed, or has no scheme, ParseError will be returned. Otherwise the
+// returned value will be nil and NotFoundError will be returned.
+func URL(key string, def ...*url.URL) (*url.URL, error) {
+	return std.URL(key, def...)
+}
+
+// IP retrieves the value of the environment variable named by the key
+// as an IPv4 or IPv6 address. Defaults and errors are handled as by
+// URL.
+func IP(key string, def ...net.IP) (net.IP, error) {
+	return std.IP(key, def...)
+}
+
+// IPNet retrieves the value of the environment variable named by the
+// key as a network in CIDR notation, such as "192.0.2.0/24", parsed by
+// net.ParseCIDR. Defaults and errors are handled as by URL.
+func IPNet(key string, def ...*net.IPNet) (*net.IPNet, error) {
+	return std.IPNet(key, def...)
+}
+
+// Prefix retrieves the value of the environment variable named by the
+// key as an IP prefix in CIDR notation, parsed by netip.ParsePrefix.
+// Defaults and errors are handled as by URL.
+func Prefix(key string, def ...netip.Prefix) (netip.Prefix, error) {
+	return std.Prefix(key, def...)
+}
+
+// HostPort retrieves the value of the environment variable named by
+// the key as an address of the form "host:port", such as a bind
+// address. The value is returned as is if it can be split by
+// net.SplitHostPort and has a numeric port. Defaults and errors are
+// handled as by URL.
+func HostPort(key string, def ...string) (string, error) {
+	return std.HostPort(key, def...)
+}
+
+// TCPAddr retrieves the value of the environment variable named by the
+// key as a TCP address, resolved by net.ResolveTCPAddr. Host names are
+// resolved at lookup time. Defaults and errors are handled as by URL.
+func TCPAddr(key string, def ...*net.TCPAddr) (*net.TCPAddr, error) {
+	return std.TCPAddr(key, def...)
+}
+
+// URLSlice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by URL.
+// Errors and defaults are handled as by IntSlice.
+func URLSlice(key string, def ...[]*url.URL) ([]*url.URL, error) {
+	return std.URLSlice(key, def...)
+}
+
+// IPSlice retrieves the value of the environment variable named by the
+// key, split as by Slice and with each element parsed as by IP. Errors
+// and defaults are handled as by IntSlice.
+func IPSlice(key string, def ...[]net.IP) ([]net.IP, error) {
+	return std.IPSlice(key, def...)
+}
+
+// IPNetSlice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by IPNet.
+// Errors and defaults are handled as by IntSlice.
+func IPNetSlice(key string, def ...[]*net.IPNet) ([]*net.IPNet, error) {
+	return std.IPNetSlice(key, def...)
+}
+
+// PrefixSlice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by
+// Prefix. Errors and defaults are handled as by IntSlice.
+func PrefixSlice(key string, def ...[]netip.Prefix) ([]netip.Prefix, error) {
+	return std.PrefixSlice(key, def...)
+}
+
+// HostPortSlice retrieves the value of the environment variable named
+// by the key, split as by Slice and with each element checked as by
+// HostPort. Errors and defaults are handled as by IntSlice.
+func HostPortSlice(key string, def ...[]string) ([]string, error) {
+	return std.HostPortSlice(key, def...)
+}
+
+// TCPAddrSlice retrieves the value of the environment variable named
+// by the key, split as by Slice and with each element resolved as by
+// TCPAddr. Errors and defaults are handled as by IntSlice.
+func TCPAddrSlice(key string, def ...[]*net.TCPAddr) ([]*net.TCPAddr, error) {
+	return std.TCPAddrSlice(key, def...)
+}
+
+// URL retrieves the value of the variable named by the key from the
+// source of e. See the package-level URL for details.
+func (e *Env) URL(key string, def ...*url.URL) (*url.URL, error) {
+	return GetFrom(e, key, def...)
+}
+
+// IP retrieves the value of the variable named by the key from the
+// source of e. See the package-level IP for details.
+func (e *Env) IP(key string, def ...net.IP) (net.IP, error) {
+	return GetFrom(e, key, def...)
+}
+
+// IPNet retrieves the value of the variable named by the key from the
+// source of e. See the package-level IPNet for details.
+func (e *Env) IPNet(key string, def ...*net.IPNet) (*net.IPNet, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Prefix retrieves the value of the variable named by the key from the
+// source of e. See the package-level Prefix for details.
+func (e *Env) Prefix(key string, def ...netip.Prefix) (netip.Prefix, error) {
+	return GetFrom(e, key, def...)
+}
+
+// HostPort retrieves the value of the variable named by the key from
+// the source of e. See the package-level HostPort for details.
+func (e *Env) HostPort(key string, def ...string) (string, error) {
+	d := make([]hostPort, len(def))
+	for i, v := range def {
+		d[i] = hostPort(v)
+	}
+	hp, err := GetFrom(e, key, d...)
+	return string(hp), err
+}
+
+// TCPAddr retrieves the value of the variable named by the key from
+// the source of e. See the package-level TCPAddr for details.
+func (e *Env) TCPAddr(key string, def ...*net.TCPAddr) (*net.TCPAddr, error) {
+	return GetFrom(e, key, def...)
+}
+
+// URLSlice retrieves the value of the variable named by the key from
+// the source of e. See the package-level URLSlice for details.
+func (e *Env) URLSlice(key string, def ...[]*url.URL) ([]*url.URL, error) {
+	return GetFrom(e, key, def...)
+}
+
+// IPSlice retrieves the value of the variable named by the key from
+// the source of e. See the package-level IPSlice for details.
+func (e *Env) IPSlice(key string, def ...[]net.IP) ([]net.IP, error) {
+	return GetFrom(e, key, def...)
+}
+
+// IPNetSlice retrieves the value of the variable named by the key from
+// the source of e. See the package-level IPNetSlice for details.
+func (e *Env) IPNetSlice(key string, def ...[]*net.IPNet) ([]*net.IPNet, error) {
+	return GetFrom(e, key, def...)
+}
+
+// PrefixSlice retrieves the value of the variable named by the key
+// from the source of e. See the package-level PrefixSlice for details.
+func (e *Env) PrefixSlice(key string, def ...[]netip.Prefix) ([]netip.Prefix, error) {
+	return GetFrom(e, key, def...)
+}
+
+// HostPortSlice retrieves the value of the variable named by the key
+// from the source of e. See the package-level HostPortSlice for
+// details.
+func (e *Env) HostPortSlice(key string, def ...[]string) ([]string, error) {
+	d := make([][]hostPort, len(def))
+	for i, v := range def {
+		d[i] = make([]hostPort, len(v))
+		for j, hp := range v {
+			d[i][j] = hostPort(hp)
+		}
+	}
+	hps, err := GetFrom(e, key, d...)
+	if hps == nil {
+		return nil, err
+	}
+	res := make([]string, len(hps))
+	for i, hp := range hps {
+		res[i] = string(hp)
+	}
+	return res, err
+}
+
+// TCPAddrSlice retrieves the value of the variable named by the key
+// from the source of e. See the package-level TCPAddrSlice for
+// details.
+func (e *Env) TCPAddrSlice(key string, def ...[]*net.TCPAddr) ([]*net.TCPAddr, error) {
+	return GetFrom(e, key, def...)
+}
+
+func parseURL(v string) (*url.URL, error) {
+	u, err := url.Parse(v)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" {
+		return nil, errors.New("missing scheme in URL")
+	}
+	return u, nil
+}
+
+func parseIP(v string) (net.IP, error) {
+	ip := net.ParseIP(v)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address \"%s\"", v)
+	}
+	return ip, nil
+}
+
+func parseHostPort(v string) (hostPort, error) {
+	_, port, err := net.SplitHostPort(v)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid port \"%s\"", port)
+	}
+	return hostPort(v), nil
+}
@@ -0,0 +1,180 @@
+package envlookup
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// parseFunc parses a variable value into a value of a specific type,
+// according to the options of e.
+type parseFunc func(e *Env, v string) (any, error)
+
+// parsersMu guards parsers.
+var parsersMu sync.RWMutex
+
+// parsers holds the parser of each supported type.
+var parsers = map[reflect.Type]parseFunc{
+	reflect.TypeOf(""): func(e *Env, v string) (any, error) {
+		return v, nil
+	},
+	reflect.TypeOf([]string(nil)): func(e *Env, v string) (any, error) {
+		return e.parseSlice(v)
+	},
+	reflect.TypeOf(int(0)):   intParser(strconv.IntSize),
+	reflect.TypeOf(int8(0)):  intParser(8),
+	reflect.TypeOf(int16(0)): intParser(16),
+	reflect.TypeOf(int32(0)): intParser(32),
+	reflect.TypeOf(int64(0)): intParser(64),
+	reflect.TypeOf(false): func(e *Env, v string) (any, error) {
+		return e.parseBool(v)
+	},
+	reflect.TypeOf(time.Duration(0)): func(e *Env, v string) (any, error) {
+		return e.parseDuration(v)
+	},
+	reflect.TypeOf(time.Time{}): func(e *Env, v string) (any, error) {
+		return e.parseTime(v)
+	},
+	reflect.TypeOf((*time.Location)(nil)): func(e *Env, v string) (any, error) {
+		return time.LoadLocation(v)
+	},
+	reflect.TypeOf(float64(0)): func(e *Env, v string) (any, error) {
+		return parseFloat64(v)
+	},
+	reflect.TypeOf((*url.URL)(nil)): func(e *Env, v string) (any, error) {
+		return parseURL(v)
+	},
+	reflect.TypeOf(net.IP(nil)): func(e *Env, v string) (any, error) {
+		return parseIP(v)
+	},
+	reflect.TypeOf((*net.IPNet)(nil)): func(e *Env, v string) (any, error) {
+		_, n, err := net.ParseCIDR(v)
+		return n, err
+	},
+	reflect.TypeOf(netip.Prefix{}): func(e *Env, v string) (any, error) {
+		return netip.ParsePrefix(v)
+	},
+	reflect.TypeOf(hostPort("")): func(e *Env, v string) (any, error) {
+		return parseHostPort(v)
+	},
+	reflect.TypeOf((*net.TCPAddr)(nil)): func(e *Env, v string) (any, error) {
+		return net.ResolveTCPAddr("tcp", v)
+	},
+	reflect.TypeOf(uint(0)):   uintParser(strconv.IntSize),
+	reflect.TypeOf(uint8(0)):  uintParser(8),
+	reflect.TypeOf(uint16(0)): uintParser(16),
+	reflect.TypeOf(uint32(0)): uintParser(32),
+	reflect.TypeOf(uint64(0)): uintParser(64),
+}
+
+// RegisterParser registers parse as the parser of values of type T.
+// Registered types are supported by Get and Load, with the same
+// default, NotFoundError and ParseError semantics as the built-in
+// types; an error returned by parse is wrapped in a ParseError naming
+// the variable. Registering a parser for a type that already has one,
+// including the built-in types, replaces it. It is intended for use
+// such as
+//
+//	envlookup.RegisterParser(func(v string) (LogLevel, error) {
+//		switch v {
+//		case "debug":
+//			return Debug, nil
+//		case "info":
+//			return Info, nil
+//		}
+//		return 0, errors.New("unknown log level")
+//	})
+//
+// RegisterParser is safe for concurrent use.
+func RegisterParser[T any](parse func(v string) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[t] = func(_ *Env, v string) (any, error) {
+		return parse(v)
+	}
+}
+
+// parserFor returns a function parsing values of type t according to
+// the options of e. Besides the types in parsers, types implementing
+// encoding.TextUnmarshaler or flag.Value, types whose underlying type
+// is in parsers and slices and maps of any supported types are
+// supported, in that order of precedence.
+func (e *Env) parserFor(t reflect.Type) (func(v string) (reflect.Value, error), bool) {
+	parsersMu.RLock()
+	p, ok := parsers[t]
+	parsersMu.RUnlock()
+	if !ok {
+		if parse, ok := methodParser(t); ok {
+			return parse, true
+		}
+		parsersMu.RLock()
+		p, ok = parsers[underlying(t)]
+		parsersMu.RUnlock()
+	}
+	if !ok && t.Kind() == reflect.Slice {
+		return e.sliceParser(t)
+	}
+	if !ok && t.Kind() == reflect.Map {
+		return e.mapParser(t)
+	}
+	if !ok {
+		return nil, false
+	}
+	return func(v string) (reflect.Value, error) {
+		res, err := p(e, v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if res == nil {
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(res).Convert(t), nil
+	}, true
+}
+
+// underlying returns the predeclared type that t is defined on, or nil
+// if there is none.
+func underlying(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.TypeOf("")
+	case reflect.Int:
+		return reflect.TypeOf(int(0))
+	case reflect.Int8:
+		return reflect.TypeOf(int8(0))
+	case reflect.Int16:
+		return reflect.TypeOf(int16(0))
+	case reflect.Int32:
+		return reflect.TypeOf(int32(0))
+	case reflect.Int64:
+		return reflect.TypeOf(int64(0))
+	case reflect.Bool:
+		return reflect.TypeOf(false)
+	case reflect.Float64:
+		return reflect.TypeOf(float64(0))
+	case reflect.Uint:
+		return reflect.TypeOf(uint(0))
+	case reflect.Uint8:
+		return reflect.TypeOf(uint8(0))
+	case reflect.Uint16:
+		return reflect.TypeOf(uint16(0))
+	case reflect.Uint32:
+		return reflect.TypeOf(uint32(0))
+	case reflect.Uint64:
+		return reflect.TypeOf(uint64(0))
+	case reflect.Slice:
+		if t.Elem() == reflect.TypeOf("") {
+			return reflect.TypeOf([]string(nil))
+		}
+	}
+	return nil
+}
+
+func parseFloat64(v string) (float64, error) {
+	return strconv.ParseFloat(v, 64)
+}
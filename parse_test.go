@@ -0,0 +1,79 @@
+package envlookup_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+type instrument int
+
+const (
+	saxophone instrument = iota + 1
+	trumpet
+)
+
+func parseInstrument(v string) (instrument, error) {
+	switch strings.ToLower(v) {
+	case "saxophone":
+		return saxophone, nil
+	case "trumpet":
+		return trumpet, nil
+	}
+	return 0, errors.New("unknown instrument")
+}
+
+type venue struct {
+	name string
+}
+
+func init() {
+	envlookup.RegisterParser(parseInstrument)
+	envlookup.RegisterParser(func(v string) (*venue, error) {
+		return &venue{v}, nil
+	})
+}
+
+func TestRegisterParser(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"INSTRUMENT":     "Trumpet",
+		"BAD_INSTRUMENT": "piano",
+		"VENUE":          "Birdland",
+	})
+
+	i, err := envlookup.GetFrom[instrument](env, "INSTRUMENT")
+	if i != trumpet || err != nil {
+		t.Error("registered parser should be used", i, err)
+	}
+
+	i, err = envlookup.GetFrom(env, "EMPTY_INSTRUMENT", saxophone)
+	if i != saxophone || err != nil {
+		t.Error("default value should be set", i, err)
+	}
+
+	_, err = envlookup.GetFrom[instrument](env, "BAD_INSTRUMENT")
+	if pe, ok := err.(*envlookup.ParseError); !ok || pe.Var != "BAD_INSTRUMENT" {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	var v struct {
+		Instrument  instrument  `env:"INSTRUMENT"`
+		Instruments *instrument `env:"INSTRUMENT"`
+		Venue       *venue      `env:"VENUE"`
+		Fallback    instrument  `env:"EMPTY_INSTRUMENT" default:"saxophone"`
+	}
+	if err := env.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Instrument != trumpet || v.Instruments == nil || *v.Instruments != trumpet {
+		t.Error("registered parser should be used by Load", v)
+	}
+	if v.Venue == nil || v.Venue.name != "Birdland" {
+		t.Error("parser for pointer type should be used by Load", v.Venue)
+	}
+	if v.Fallback != saxophone {
+		t.Error("default value should be parsed", v.Fallback)
+	}
+}
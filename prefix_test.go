@@ -0,0 +1,65 @@
+package envlookup_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestWithPrefix(t *testing.T) {
+	os.Setenv("QUARTET_JAZZ_ARTIST", "McCoy Tyner")
+	defer os.Unsetenv("QUARTET_JAZZ_ARTIST")
+
+	s, err := envlookup.WithPrefix("QUARTET_").String("JAZZ_ARTIST")
+	if s != "McCoy Tyner" || err != nil {
+		t.Error("prefixed value should be set", s, err)
+	}
+
+	_, err = envlookup.WithPrefix("QUARTET_").String("RECORD_LABELS")
+	if nf, ok := err.(*envlookup.NotFoundError); !ok || nf.Var != "QUARTET_RECORD_LABELS" {
+		t.Error("error should name the prefixed variable", err)
+	}
+}
+
+func TestWithPrefixNested(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"BILLING_DB_HOST": "billing.example.com",
+		"BILLING_DB_PORT": "many",
+	}).WithPrefix("BILLING_").WithPrefix("DB_")
+
+	s, err := env.String("HOST")
+	if s != "billing.example.com" || err != nil {
+		t.Error("prefixes should accumulate", s, err)
+	}
+	_, err = envlookup.GetFrom[int](env, "PORT")
+	if pe, ok := err.(*envlookup.ParseError); !ok || pe.Var != "BILLING_DB_PORT" {
+		t.Error("error should name the prefixed variable", err)
+	}
+}
+
+type database struct {
+	Host string `env:"DB_HOST"`
+	Port int    `env:"DB_PORT" default:"5432"`
+}
+
+func TestLoadPrefix(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"BILLING_DB_HOST": "billing.example.com",
+		"AUTH_DB_HOST":    "auth.example.com",
+		"AUTH_DB_PORT":    "6432",
+	})
+	var v struct {
+		Billing database  `prefix:"BILLING_"`
+		Auth    *database `prefix:"AUTH_"`
+	}
+	if err := env.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Billing.Host != "billing.example.com" || v.Billing.Port != 5432 {
+		t.Error("prefixed struct should be loaded", v.Billing)
+	}
+	if v.Auth == nil || v.Auth.Host != "auth.example.com" || v.Auth.Port != 6432 {
+		t.Error("prefixed struct pointer should be loaded", v.Auth)
+	}
+}
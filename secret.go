@@ -0,0 +1,231 @@
+package envlookup
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// redacted replaces sensitive values in output.
+const redacted = "[REDACTED]"
+
+// SecretString is a string that is redacted when formatted, so that
+// secrets such as passwords don't end up in logs by accident. Convert
+// it to string or call Value to get the real value. Lookups of
+// SecretString values are sensitive, see Sensitive.
+type SecretString string
+
+// Value returns the real value of s.
+func (s SecretString) Value() string {
+	return string(s)
+}
+
+// String returns a redacted placeholder instead of s.
+func (s SecretString) String() string {
+	return redacted
+}
+
+// GoString returns a redacted placeholder instead of s, for the %#v
+// verb.
+func (s SecretString) GoString() string {
+	return "envlookup.SecretString(\"" + redacted + "\")"
+}
+
+// MarshalText returns a redacted placeholder instead of s, so that
+// encodings such as JSON don't reveal it either.
+func (s SecretString) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+var secretStringType = reflect.TypeOf(SecretString(""))
+
+// Secret retrieves the value of the environment variable named by the
+// key as a SecretString. Defaults and errors are handled as by String,
+// but errors never contain the value.
+func Secret(key string, def ...SecretString) (SecretString, error) {
+	return std.Secret(key, def...)
+}
+
+// Secret retrieves the value of the variable named by the key from the
+// source of e. See the package-level Secret for details.
+func (e *Env) Secret(key string, def ...SecretString) (SecretString, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Sensitive marks values as sensitive, so that they are redacted from
+// errors: the messages of the errors wrapped by ParseError and
+// ValidationError are replaced by a generic one, as is the Value of
+// ValidationError and the Key of PairError. ElementError and PairError
+// still report the position of the failing element. The underlying
+// errors can still be reached with errors.Unwrap. Values of type
+// SecretString, and slices, maps (by key or value) and pointers of
+// them, are always sensitive.
+func Sensitive() Option {
+	return func(e *Env) {
+		e.sensitive = true
+	}
+}
+
+// forType returns e, or a copy of e with the Sensitive option if
+// values of type t are secrets.
+func (e *Env) forType(t reflect.Type) *Env {
+	if e.sensitive || !secretType(t) {
+		return e
+	}
+	return e.With(Sensitive())
+}
+
+// secretType reports whether t is SecretString, or a pointer, slice
+// or map holding it.
+func secretType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		return secretType(t.Elem())
+	case reflect.Map:
+		return secretType(t.Key()) || secretType(t.Elem())
+	}
+	return t == secretStringType
+}
+
+// redactError returns err with a generic message if the values of e
+// are sensitive, since the message of err may contain the value.
+// ElementError and PairError are returned as is, as they are built
+// from redacted errors and keys already.
+func (e *Env) redactError(err error) error {
+	if !e.sensitive || err == nil {
+		return err
+	}
+	switch err.(type) {
+	case *ElementError, *PairError, *redactedError:
+		return err
+	}
+	return &redactedError{err}
+}
+
+// redactValue returns v, or a placeholder if the values of e are
+// sensitive.
+func (e *Env) redactValue(v string) string {
+	if e.sensitive {
+		return redacted
+	}
+	return v
+}
+
+// redactedError replaces the message of err, which may contain a
+// sensitive value.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return "invalid value " + redacted
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Dump returns the env-tagged fields of the struct, or pointer to
+// struct, v as lines of the form KEY=value, for logging the
+// configuration loaded by Load. Nested structs are dumped with their
+// prefix tags applied; pointers to a struct type that is already being
+// dumped are skipped, as by Load. Fields tagged with `sensitive:"true"`,
+// SecretString values and the passwords of URLs are redacted.
+func Dump(v any) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	var b strings.Builder
+	dumpStruct(&b, "", rv, nil)
+	return b.String()
+}
+
+// dumpStruct dumps the fields of rv. path holds the struct types being
+// dumped, from the outermost down to the one containing rv.
+func dumpStruct(b *strings.Builder, prefix string, rv reflect.Value, path []reflect.Type) {
+	rt := rv.Type()
+	path = append(path[:len(path):len(path)], rt)
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+
+		key, tagged := sf.Tag.Lookup("env")
+		if key == "-" {
+			continue
+		}
+		if !tagged {
+			if sf.PkgPath != "" && !sf.Anonymous {
+				continue
+			}
+			nested := prefix + sf.Tag.Get("prefix")
+			switch {
+			case fv.Kind() == reflect.Struct:
+				dumpStruct(b, nested, fv, path)
+			case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct && !fv.IsNil():
+				if !inPath(path, fv.Type().Elem()) {
+					dumpStruct(b, nested, fv.Elem(), path)
+				}
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		value := redacted
+		if !tagBool(sf, "sensitive") {
+			value = dumpValue(fv)
+		}
+		fmt.Fprintf(b, "%s%s=%s\n", prefix, key, value)
+	}
+}
+
+// dumpValue formats fv for Dump. Values are formatted by their String
+// or Error method, if they have one, so that types such as
+// SecretString can redact themselves. URLs are formatted with their
+// password redacted. Slices and maps are formatted as lists, in the
+// format that Slice and Map parse.
+func dumpValue(fv reflect.Value) string {
+	if fv.Kind() == reflect.Ptr && fv.IsNil() {
+		return ""
+	}
+	iv := fv
+	if fv.CanAddr() && fv.Kind() != reflect.Ptr {
+		// Use methods with pointer receivers, such as those of url.URL.
+		iv = fv.Addr()
+	}
+	switch v := iv.Interface().(type) {
+	case *url.URL:
+		return v.Redacted()
+	case url.URL:
+		return v.Redacted()
+	case fmt.Stringer, error:
+		return fmt.Sprint(v)
+	}
+
+	switch fv.Kind() {
+	case reflect.Ptr:
+		return dumpValue(fv.Elem())
+	case reflect.Slice, reflect.Array:
+		elems := make([]string, fv.Len())
+		for i := range elems {
+			elems[i] = dumpValue(fv.Index(i))
+		}
+		return strings.Join(elems, ",")
+	case reflect.Map:
+		pairs := make([]string, 0, fv.Len())
+		iter := fv.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, dumpValue(iter.Key())+"="+dumpValue(iter.Value()))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	}
+	return fmt.Sprint(fv.Interface())
+}
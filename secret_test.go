@@ -0,0 +1,235 @@
+package envlookup_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var secretSrc = envlookup.MapSource{
+	"DB_PASSWORD": "hunter2",
+	"PIN":         "hunter2",
+	"DB_HOST":     "db.example.com",
+}
+
+func TestSecret(t *testing.T) {
+	env := envlookup.New(secretSrc)
+	s, err := env.Secret("DB_PASSWORD")
+	if s.Value() != "hunter2" || string(s) != "hunter2" || err != nil {
+		t.Error("real value should be returned", err)
+	}
+
+	for _, out := range []string{
+		s.String(),
+		fmt.Sprint(s),
+		fmt.Sprintf("%s %v %q %#v %+v", s, s, s, s, struct{ P envlookup.SecretString }{s}),
+	} {
+		if strings.Contains(out, "hunter2") {
+			t.Error("formatted value should be redacted", out)
+		}
+	}
+	b, err := json.Marshal(map[string]envlookup.SecretString{"password": s})
+	if strings.Contains(string(b), "hunter2") || err != nil {
+		t.Error("encoded value should be redacted", string(b), err)
+	}
+
+	s, err = env.Secret("MISSING", "swordfish")
+	if s.Value() != "swordfish" || err != nil {
+		t.Error("default value should be returned", err)
+	}
+}
+
+func TestSensitive(t *testing.T) {
+	env := envlookup.New(secretSrc)
+	_, err := env.Int("PIN")
+	if err == nil || !strings.Contains(err.Error(), "hunter2") {
+		t.Fatal("values should not be redacted by default", err)
+	}
+
+	_, err = env.With(envlookup.Sensitive()).Int("PIN")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("value should be redacted", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("underlying error should be unwrappable", err)
+	}
+
+	_, err = env.With(envlookup.Sensitive(), envlookup.Regex("^[0-9]+$")).String("PIN")
+	var verr *envlookup.ValidationError
+	if !errors.As(err, &verr) || verr.Value == "hunter2" || strings.Contains(err.Error(), "hunter2") {
+		t.Error("validation error should be redacted", err)
+	}
+
+	_, err = env.With(envlookup.Regex("^[0-9]+$")).Secret("PIN")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("secrets should always be redacted", err)
+	}
+}
+
+func TestSensitiveShortValue(t *testing.T) {
+	_, err := envlookup.New(envlookup.MapSource{"DEBUG": "e"}, envlookup.Sensitive()).Bool("DEBUG")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Fatal("error should be envlookup.ParseError", err)
+	}
+	want := `could not parse environment variable "DEBUG": invalid value [REDACTED]`
+	if err.Error() != want {
+		t.Errorf("message should be generic: got %q, want %q", err, want)
+	}
+}
+
+func TestSensitiveSliceAndMap(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"PORTS":     "1,hunter2",
+		"LIMITS":    "a=1,b=hunter2",
+		"BAD_KEYS":  "hunter2=1",
+		"DUPLICATE": "hunter2=1,hunter2=2",
+	}, envlookup.Sensitive())
+
+	_, err := env.IntSlice("PORTS")
+	var elemErr *envlookup.ElementError
+	if !errors.As(err, &elemErr) || elemErr.Index != 1 {
+		t.Error("error should wrap envlookup.ElementError with the index", err)
+	}
+	if err == nil || strings.Contains(err.Error(), "hunter2") || elemErr.Error() != "element 1: invalid value [REDACTED]" {
+		t.Error("element should be redacted", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("underlying error should be unwrappable", err)
+	}
+
+	for _, key := range []string{"LIMITS", "DUPLICATE"} {
+		_, err = env.IntMap(key)
+		var pairErr *envlookup.PairError
+		if !errors.As(err, &pairErr) || pairErr.Index != 1 {
+			t.Error("error should wrap envlookup.PairError with the index", key, err)
+		}
+		if strings.Contains(err.Error(), "hunter2") || pairErr.Key == "hunter2" {
+			t.Error("pair should be redacted", key, err)
+		}
+	}
+
+	_, err = envlookup.GetFrom[map[int]int](env, "BAD_KEYS")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("key should be redacted", err)
+	}
+
+	var v struct {
+		Ports []int `env:"PORTS" sensitive:"true"`
+	}
+	err = envlookup.New(envlookup.MapSource{"PORTS": "1,hunter2"}).Load(&v)
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("sensitive field should be redacted", err)
+	}
+}
+
+func TestSecretMap(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"M": "db=hunter2"}, envlookup.Min(100))
+	_, err := envlookup.GetFrom[map[string]envlookup.SecretString](env, "M")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("map of secrets should be redacted", err)
+	}
+	_, err = envlookup.GetFrom[map[envlookup.SecretString]int](env, "M")
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("map with secret keys should be redacted", err)
+	}
+
+	var v struct {
+		M map[string]envlookup.SecretString `env:"M" regex:"^x"`
+	}
+	err = envlookup.New(envlookup.MapSource{"M": "db=hunter2"}).Load(&v)
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("map field of secrets should be redacted", err)
+	}
+}
+
+func TestLoadSensitive(t *testing.T) {
+	var v struct {
+		Pin int `env:"PIN" sensitive:"true"`
+	}
+	err := envlookup.New(secretSrc).Load(&v)
+	if err == nil || strings.Contains(err.Error(), "hunter2") {
+		t.Error("value should be redacted", err)
+	}
+}
+
+func TestDump(t *testing.T) {
+	type database struct {
+		Host     string                 `env:"DB_HOST"`
+		Password envlookup.SecretString `env:"DB_PASSWORD"`
+	}
+	var v struct {
+		Billing database `prefix:"BILLING_"`
+		Pin     string   `env:"PIN" sensitive:"true"`
+		Port    *int     `env:"PORT"`
+		Ignored string   `env:"-"`
+	}
+	v.Billing.Host = "db.example.com"
+	v.Billing.Password = "hunter2"
+	v.Pin = "hunter2"
+
+	want := "BILLING_DB_HOST=db.example.com\nBILLING_DB_PASSWORD=[REDACTED]\nPIN=[REDACTED]\nPORT=\n"
+	if got := envlookup.Dump(&v); got != want {
+		t.Errorf("config should be dumped: got %q, want %q", got, want)
+	}
+}
+
+func TestDumpCycle(t *testing.T) {
+	type node struct {
+		Name string `env:"NAME"`
+		Next *node  `prefix:"NEXT_"`
+	}
+	n := &node{Name: "a"}
+	n.Next = n
+	if got := envlookup.Dump(n); got != "NAME=a\n" {
+		t.Errorf("cyclic struct should be dumped once: got %q", got)
+	}
+}
+
+func TestDumpValues(t *testing.T) {
+	u, _ := url.Parse("postgres://u:p@h/db")
+	port := 5432
+	var v struct {
+		DB       *url.URL                 `env:"DB"`
+		Mirror   url.URL                  `env:"MIRROR"`
+		Replicas []*url.URL               `env:"REPLICAS"`
+		Port     *int                     `env:"PORT"`
+		IPs      []net.IP                 `env:"IPS"`
+		Limits   map[string]int           `env:"LIMITS"`
+		Timeout  time.Duration            `env:"TIMEOUT"`
+		Secrets  []envlookup.SecretString `env:"SECRETS"`
+	}
+	v.DB, v.Mirror, v.Replicas = u, *u, []*url.URL{u, u}
+	v.Port = &port
+	v.IPs = []net.IP{net.IPv4(192, 0, 2, 1), net.IPv4(192, 0, 2, 2)}
+	v.Limits = map[string]int{"b": 2, "a": 1}
+	v.Timeout = 90 * time.Second
+	v.Secrets = []envlookup.SecretString{"hunter2"}
+
+	want := strings.Join([]string{
+		"DB=postgres://u:xxxxx@h/db",
+		"MIRROR=postgres://u:xxxxx@h/db",
+		"REPLICAS=postgres://u:xxxxx@h/db,postgres://u:xxxxx@h/db",
+		"PORT=5432",
+		"IPS=192.0.2.1,192.0.2.2",
+		"LIMITS=a=1,b=2",
+		"TIMEOUT=1m30s",
+		"SECRETS=[REDACTED]",
+	}, "\n") + "\n"
+	if got := envlookup.Dump(&v); got != want {
+		t.Errorf("values should be dumped: got %q, want %q", got, want)
+	}
+	if got := envlookup.Dump(v); got != want {
+		t.Errorf("struct value should be dumped: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,240 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ElementError indicates that an element of a list could not be
+// parsed. It is wrapped in a ParseError naming the variable.
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %s", e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// IntSlice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by Int.
+// If an element could not be parsed, ParseError wrapping an
+// ElementError with the index of the element will be returned. An
+// empty value yields an empty slice, as with Map, rather than a single
+// empty element as with Slice. Defaults and NotFoundError are handled
+// as by Slice.
+func IntSlice(key string, def ...[]int) ([]int, error) {
+	return std.IntSlice(key, def...)
+}
+
+// Int64Slice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by Int64.
+// Errors and defaults are handled as by IntSlice.
+func Int64Slice(key string, def ...[]int64) ([]int64, error) {
+	return std.Int64Slice(key, def...)
+}
+
+// Uint64Slice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by
+// Uint64. Errors and defaults are handled as by IntSlice.
+func Uint64Slice(key string, def ...[]uint64) ([]uint64, error) {
+	return std.Uint64Slice(key, def...)
+}
+
+// Float64Slice retrieves the value of the environment variable named
+// by the key, split as by Slice and with each element parsed as by
+// Float64. Errors and defaults are handled as by IntSlice.
+func Float64Slice(key string, def ...[]float64) ([]float64, error) {
+	return std.Float64Slice(key, def...)
+}
+
+// BoolSlice retrieves the value of the environment variable named by
+// the key, split as by Slice and with each element parsed as by Bool.
+// Errors and defaults are handled as by IntSlice.
+func BoolSlice(key string, def ...[]bool) ([]bool, error) {
+	return std.BoolSlice(key, def...)
+}
+
+// DurationSlice retrieves the value of the environment variable named
+// by the key, split as by Slice and with each element parsed as by
+// Duration. Errors and defaults are handled as by IntSlice.
+func DurationSlice(key string, def ...[]time.Duration) ([]time.Duration, error) {
+	return std.DurationSlice(key, def...)
+}
+
+// IntSlice retrieves the value of the variable named by the key from
+// the source of e. See the package-level IntSlice for details.
+func (e *Env) IntSlice(key string, def ...[]int) ([]int, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Int64Slice retrieves the value of the variable named by the key from
+// the source of e. See the package-level Int64Slice for details.
+func (e *Env) Int64Slice(key string, def ...[]int64) ([]int64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Uint64Slice retrieves the value of the variable named by the key
+// from the source of e. See the package-level Uint64Slice for details.
+func (e *Env) Uint64Slice(key string, def ...[]uint64) ([]uint64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Float64Slice retrieves the value of the variable named by the key
+// from the source of e. See the package-level Float64Slice for
+// details.
+func (e *Env) Float64Slice(key string, def ...[]float64) ([]float64, error) {
+	return GetFrom(e, key, def...)
+}
+
+// BoolSlice retrieves the value of the variable named by the key from
+// the source of e. See the package-level BoolSlice for details.
+func (e *Env) BoolSlice(key string, def ...[]bool) ([]bool, error) {
+	return GetFrom(e, key, def...)
+}
+
+// DurationSlice retrieves the value of the variable named by the key
+// from the source of e. See the package-level DurationSlice for
+// details.
+func (e *Env) DurationSlice(key string, def ...[]time.Duration) ([]time.Duration, error) {
+	return GetFrom(e, key, def...)
+}
+
+// Separator sets the separator that Slice splits values on. The
+// default separator is ",".
+func Separator(sep string) Option {
+	return func(e *Env) {
+		e.sep = sep
+	}
+}
+
+// TrimSpace makes Slice trim leading and trailing white space from
+// each element.
+func TrimSpace() Option {
+	return func(e *Env) {
+		e.trim = true
+	}
+}
+
+// DropEmpty makes Slice drop empty elements, so that "a,,b" yields
+// two elements. Elements given as "" with Quoted are kept.
+func DropEmpty() Option {
+	return func(e *Env) {
+		e.dropEmpty = true
+	}
+}
+
+// Quoted makes Slice quote-aware: separators within double quotes do
+// not split, and the quotes around an element are removed, so that
+// a,"b,c" yields the two elements a and b,c. Within quotes, \" and \\
+// denote a literal quote and backslash.
+func Quoted() Option {
+	return func(e *Env) {
+		e.quoted = true
+	}
+}
+
+// parseSlice splits v into elements according to the options of e.
+func (e *Env) parseSlice(v string) ([]string, error) {
+	var elems []string
+	if e.quoted {
+		var err error
+		if elems, err = splitQuoted(v, e.sep); err != nil {
+			return nil, err
+		}
+	} else {
+		elems = strings.Split(v, e.sep)
+	}
+
+	res := elems[:0]
+	for _, elem := range elems {
+		if e.trim {
+			elem = strings.TrimSpace(elem)
+		}
+		if e.quoted && len(elem) >= 2 && elem[0] == '"' && elem[len(elem)-1] == '"' {
+			res = append(res, unquote(elem[1:len(elem)-1]))
+			continue
+		}
+		if e.dropEmpty && elem == "" {
+			continue
+		}
+		res = append(res, elem)
+	}
+	return res, nil
+}
+
+// splitQuoted splits v on sep, except within double quotes. The
+// quotes are kept.
+func splitQuoted(v, sep string) ([]string, error) {
+	var (
+		elems  []string
+		start  int
+		quoted bool
+	)
+	for i := 0; i < len(v); i++ {
+		switch {
+		case quoted && v[i] == '\\':
+			i++
+		case v[i] == '"':
+			quoted = !quoted
+		case !quoted && sep != "" && strings.HasPrefix(v[i:], sep):
+			elems = append(elems, v[start:i])
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+	if quoted {
+		return nil, errors.New("unterminated quoted element")
+	}
+	return append(elems, v[start:]), nil
+}
+
+// unquote replaces the escape sequences \" and \\ in s.
+func unquote(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '"' || s[i+1] == '\\') {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// sliceParser returns a function splitting values as by Slice and
+// parsing each element into the element type of t, if that is
+// supported.
+func (e *Env) sliceParser(t reflect.Type) (func(v string) (reflect.Value, error), bool) {
+	parse, ok := e.parserFor(t.Elem())
+	if !ok {
+		return nil, false
+	}
+	return func(v string) (reflect.Value, error) {
+		if v == "" {
+			return reflect.MakeSlice(t, 0, 0), nil
+		}
+		elems, err := e.parseSlice(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		res := reflect.MakeSlice(t, len(elems), len(elems))
+		for i, elem := range elems {
+			ev, err := parse(elem)
+			if err != nil {
+				return reflect.Value{}, &ElementError{i, e.redactError(err)}
+			}
+			res.Index(i).Set(ev)
+		}
+		return res, nil
+	}, true
+}
@@ -0,0 +1,141 @@
+package envlookup_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestSliceOptions(t *testing.T) {
+	src := envlookup.MapSource{
+		"LABELS":        "Impulse!, Atlantic,,Blue Note ",
+		"QUOTED_LABELS": `Impulse!, "Atlantic, Records" ,"",Blue "Note"`,
+		"ESCAPED":       `"say \"hi\"","back\\slash"`,
+		"UNTERMINATED":  `Impulse!,"Atlantic`,
+		"SEMICOLONS":    "Impulse!;Atlantic",
+	}
+	tests := []struct {
+		key  string
+		opts []envlookup.Option
+		want []string
+	}{
+		{"LABELS", nil, []string{"Impulse!", " Atlantic", "", "Blue Note "}},
+		{"LABELS", []envlookup.Option{envlookup.TrimSpace()}, []string{"Impulse!", "Atlantic", "", "Blue Note"}},
+		{"LABELS", []envlookup.Option{envlookup.DropEmpty()}, []string{"Impulse!", " Atlantic", "Blue Note "}},
+		{"LABELS", []envlookup.Option{envlookup.TrimSpace(), envlookup.DropEmpty()}, []string{"Impulse!", "Atlantic", "Blue Note"}},
+		{"SEMICOLONS", []envlookup.Option{envlookup.Separator(";")}, []string{"Impulse!", "Atlantic"}},
+		{"QUOTED_LABELS", []envlookup.Option{envlookup.Quoted(), envlookup.TrimSpace(), envlookup.DropEmpty()}, []string{"Impulse!", "Atlantic, Records", "", `Blue "Note"`}},
+		{"ESCAPED", []envlookup.Option{envlookup.Quoted()}, []string{`say "hi"`, `back\slash`}},
+	}
+	for _, test := range tests {
+		val, err := envlookup.New(src, test.opts...).Slice(test.key)
+		if err != nil {
+			t.Error("error should be nil", test.key, err)
+		}
+		if !reflect.DeepEqual(val, test.want) {
+			t.Errorf("value should be split: got %q, want %q", val, test.want)
+		}
+	}
+
+	_, err := envlookup.New(src, envlookup.Quoted()).Slice("UNTERMINATED")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestWithSliceOptions(t *testing.T) {
+	val, err := envlookup.With(envlookup.Separator("!")).Slice("RECORD_LABELS")
+	want := []string{"Impulse", ",Atlantic,Prestige,Blue Note"}
+	if !reflect.DeepEqual(val, want) || err != nil {
+		t.Errorf("value should be split: got %q, want %q", val, want)
+	}
+}
+
+func TestLoadSliceTags(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"LABELS": ` Impulse! ;; "Atlantic;Records"`})
+	var v struct {
+		Labels []string `env:"LABELS" sep:";" trim:"true" dropempty:"true" quoted:"true"`
+		Raw    []string `env:"LABELS"`
+	}
+	if err := env.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if want := []string{"Impulse!", "Atlantic;Records"}; !reflect.DeepEqual(v.Labels, want) {
+		t.Errorf("tags should configure splitting: got %q, want %q", v.Labels, want)
+	}
+	if len(v.Raw) != 1 {
+		t.Error("tags should only apply to their field", v.Raw)
+	}
+}
+
+func TestTypedSlices(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{
+		"TRACKS":    "4, 7, 5",
+		"SALES":     "1.5,2.25",
+		"REISSUED":  "true,0,1",
+		"DURATIONS": "7m42s,33m2s",
+		"BAD":       "4,7,five",
+		"PORTS":     "",
+	}, envlookup.TrimSpace())
+
+	if v, err := env.IntSlice("TRACKS"); !reflect.DeepEqual(v, []int{4, 7, 5}) || err != nil {
+		t.Error("int elements should be parsed", v, err)
+	}
+	if v, err := env.Int64Slice("TRACKS"); !reflect.DeepEqual(v, []int64{4, 7, 5}) || err != nil {
+		t.Error("int64 elements should be parsed", v, err)
+	}
+	if v, err := env.Uint64Slice("TRACKS"); !reflect.DeepEqual(v, []uint64{4, 7, 5}) || err != nil {
+		t.Error("uint64 elements should be parsed", v, err)
+	}
+	if v, err := env.Float64Slice("SALES"); !reflect.DeepEqual(v, []float64{1.5, 2.25}) || err != nil {
+		t.Error("float64 elements should be parsed", v, err)
+	}
+	if v, err := env.BoolSlice("REISSUED"); !reflect.DeepEqual(v, []bool{true, false, true}) || err != nil {
+		t.Error("bool elements should be parsed", v, err)
+	}
+	want := []time.Duration{7*time.Minute + 42*time.Second, 33*time.Minute + 2*time.Second}
+	if v, err := env.DurationSlice("DURATIONS"); !reflect.DeepEqual(v, want) || err != nil {
+		t.Error("duration elements should be parsed", v, err)
+	}
+	if v, err := env.IntSlice("EMPTY", []int{1}); !reflect.DeepEqual(v, []int{1}) || err != nil {
+		t.Error("default value should be set", v, err)
+	}
+
+	if v, err := env.IntSlice("PORTS"); v == nil || len(v) != 0 || err != nil {
+		t.Error("empty value should yield an empty slice", v, err)
+	}
+	if v, err := env.DurationSlice("PORTS"); v == nil || len(v) != 0 || err != nil {
+		t.Error("empty value should yield an empty slice", v, err)
+	}
+
+	_, err := env.IntSlice("BAD")
+	var pe *envlookup.ParseError
+	var ee *envlookup.ElementError
+	if !errors.As(err, &pe) || pe.Var != "BAD" {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+	if !errors.As(err, &ee) || ee.Index != 2 {
+		t.Error("error should report the element index", err)
+	}
+}
+
+func TestLoadTypedSlices(t *testing.T) {
+	type track string
+	env := envlookup.New(envlookup.MapSource{"TRACKS": "Acknowledgement,Resolution", "COUNTS": "4,7"})
+	var v struct {
+		Tracks []track     `env:"TRACKS"`
+		Counts []complex64 `env:"COUNTS"`
+		Ints   []int       `env:"COUNTS"`
+	}
+	err := env.Load(&v)
+	var ut *envlookup.UnsupportedTypeError
+	if !errors.As(err, &ut) || ut.Field != "Counts" {
+		t.Error("error should be envlookup.UnsupportedTypeError", err)
+	}
+	if len(v.Tracks) != 2 || v.Tracks[1] != "Resolution" || !reflect.DeepEqual(v.Ints, []int{4, 7}) {
+		t.Error("slice elements should be parsed", v)
+	}
+}
@@ -0,0 +1,37 @@
+package envlookup
+
+import "os"
+
+// Source is the interface that wraps the Lookup method.
+//
+// Lookup retrieves the value of the variable named by the key. If the
+// variable is present the value (which may be empty) is returned and
+// the boolean is true. Otherwise the returned value will be empty and
+// the boolean will be false.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// SourceFunc is an adapter to allow the use of ordinary functions as a
+// Source.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource is a Source that looks up variables in a map. It is useful
+// for tests and for values read from elsewhere.
+type MapSource map[string]string
+
+// Lookup returns the value stored in m under the key.
+func (m MapSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// OS is the Source of the process environment. It is used by the
+// package-level functions, which read it at every lookup, so that
+// replacing it, as in tests, affects them too.
+var OS Source = SourceFunc(os.LookupEnv)
@@ -0,0 +1,43 @@
+package envlookup_test
+
+import (
+	"testing"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+func TestMapSource(t *testing.T) {
+	src := envlookup.MapSource{"JAZZ_ARTIST": "Miles Davis"}
+	v, ok := src.Lookup("JAZZ_ARTIST")
+	if !ok || v != "Miles Davis" {
+		t.Error("value should be found", v, ok)
+	}
+	if _, ok := src.Lookup("EMPTY_JAZZ_ARTIST"); ok {
+		t.Error("value should not be found")
+	}
+}
+
+func TestSourceFunc(t *testing.T) {
+	src := envlookup.SourceFunc(func(key string) (string, bool) {
+		return key + "!", true
+	})
+	v, ok := src.Lookup("Impulse")
+	if !ok || v != "Impulse!" {
+		t.Error("value should be returned by the function", v, ok)
+	}
+}
+
+func TestOSSource(t *testing.T) {
+	v, ok := envlookup.OS.Lookup("JAZZ_ARTIST")
+	if !ok || v != "John Coltrane" {
+		t.Error("value should be read from the environment", v, ok)
+	}
+}
+
+func TestReplaceOS(t *testing.T) {
+	defer func(src envlookup.Source) { envlookup.OS = src }(envlookup.OS)
+	envlookup.OS = envlookup.MapSource{"JAZZ_ARTIST": "Alice Coltrane"}
+	if v, err := envlookup.String("JAZZ_ARTIST"); v != "Alice Coltrane" || err != nil {
+		t.Error("package-level functions should use the replaced OS", v, err)
+	}
+}
@@ -0,0 +1,97 @@
+package envlookup
+
+import (
+	"encoding"
+	"flag"
+	"reflect"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	flagValueType       = reflect.TypeOf((*flag.Value)(nil)).Elem()
+)
+
+// Text retrieves the value of the environment variable named by the
+// key and unmarshals it into target using its UnmarshalText method. If
+// the variable is not present but a default value is supplied, that
+// value is unmarshaled instead. If unmarshaling fails, ParseError will
+// be returned. Otherwise, if the variable is not present, target is
+// left untouched and NotFoundError will be returned.
+func Text(key string, target encoding.TextUnmarshaler, def ...string) error {
+	return std.Text(key, target, def...)
+}
+
+// Text retrieves the value of the variable named by the key from the
+// source of e. See the package-level Text for details.
+func (e *Env) Text(key string, target encoding.TextUnmarshaler, def ...string) error {
+	return e.decode(key, def, func(v string) error {
+		return target.UnmarshalText([]byte(v))
+	})
+}
+
+// Var retrieves the value of the environment variable named by the
+// key and sets it on target using its Set method, as the flag package
+// does for command-line flags. Defaults and errors are handled as by
+// Text.
+func Var(key string, target flag.Value, def ...string) error {
+	return std.Var(key, target, def...)
+}
+
+// Var retrieves the value of the variable named by the key from the
+// source of e. See the package-level Var for details.
+func (e *Env) Var(key string, target flag.Value, def ...string) error {
+	return e.decode(key, def, target.Set)
+}
+
+// decode looks up the key and passes its value, or the default, to
+// set.
+func (e *Env) decode(key string, def []string, set func(v string) error) error {
+	key = e.varName(key)
+	v, err := e.lookup(key)
+	if err != nil {
+		if len(def) == 0 || !missing(err) {
+			return err
+		}
+		v = def[0]
+	}
+	if err := set(v); err != nil {
+		return &ParseError{key, e.redactError(err)}
+	}
+	return nil
+}
+
+// methodParser returns a function parsing values of type t through
+// its UnmarshalText or Set method, if t or a pointer to t has one.
+func methodParser(t reflect.Type) (func(v string) (reflect.Value, error), bool) {
+	// For pointer types, a new value is allocated for the pointer to
+	// point to.
+	alloc, elem := reflect.PointerTo(t), false
+	if t.Kind() == reflect.Ptr {
+		alloc, elem = t, true
+	}
+
+	var set func(p reflect.Value, v string) error
+	switch {
+	case alloc.Implements(textUnmarshalerType):
+		set = func(p reflect.Value, v string) error {
+			return p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v))
+		}
+	case alloc.Implements(flagValueType):
+		set = func(p reflect.Value, v string) error {
+			return p.Interface().(flag.Value).Set(v)
+		}
+	default:
+		return nil, false
+	}
+
+	return func(v string) (reflect.Value, error) {
+		p := reflect.New(alloc.Elem())
+		if err := set(p, v); err != nil {
+			return reflect.Value{}, err
+		}
+		if elem {
+			return p, nil
+		}
+		return p.Elem(), nil
+	}, true
+}
@@ -0,0 +1,99 @@
+package envlookup_test
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+// members is a flag.Value collecting band members.
+type members []string
+
+func (m *members) String() string {
+	return strings.Join(*m, "+")
+}
+
+func (m *members) Set(v string) error {
+	if v == "" {
+		return errors.New("no members")
+	}
+	*m = strings.Split(v, "+")
+	return nil
+}
+
+var textEnv = envlookup.New(envlookup.MapSource{
+	"VENUE_IP":     "192.0.2.1",
+	"BAD_VENUE_IP": "192.0.2",
+	"RECORDED_AT":  "1964-12-09T20:00:00Z",
+	"QUARTET":      "Coltrane+Tyner+Garrison+Jones",
+	"NO_MEMBERS":   "",
+	"SALES":        "123456789012345678901234567890",
+})
+
+func TestText(t *testing.T) {
+	var ip net.IP
+	if err := textEnv.Text("VENUE_IP", &ip); err != nil || ip.String() != "192.0.2.1" {
+		t.Error("value should be unmarshaled", ip, err)
+	}
+
+	err := textEnv.Text("BAD_VENUE_IP", &ip)
+	if pe, ok := err.(*envlookup.ParseError); !ok || pe.Var != "BAD_VENUE_IP" {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	err = textEnv.Text("EMPTY_VENUE_IP", &ip, "198.51.100.1")
+	if err != nil || ip.String() != "198.51.100.1" {
+		t.Error("default value should be unmarshaled", ip, err)
+	}
+
+	err = textEnv.Text("EMPTY_VENUE_IP", &ip)
+	if _, ok := err.(*envlookup.NotFoundError); !ok {
+		t.Error("error should be envlookup.NotFoundError", err)
+	}
+}
+
+func TestVar(t *testing.T) {
+	var m members
+	if err := textEnv.Var("QUARTET", &m); err != nil || len(m) != 4 {
+		t.Error("value should be set", m, err)
+	}
+
+	err := textEnv.Var("NO_MEMBERS", &m)
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
+
+func TestTextAutomatic(t *testing.T) {
+	at, err := envlookup.GetFrom[time.Time](textEnv, "RECORDED_AT")
+	if err != nil || at.Year() != 1964 {
+		t.Error("time value should be unmarshaled", at, err)
+	}
+
+	var v struct {
+		IP      net.IP   `env:"VENUE_IP"`
+		Members members  `env:"QUARTET"`
+		Sales   *big.Int `env:"SALES"`
+	}
+	if err := textEnv.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.IP.String() != "192.0.2.1" || len(v.Members) != 4 {
+		t.Error("values should be unmarshaled", v)
+	}
+	if v.Sales == nil || v.Sales.String() != "123456789012345678901234567890" {
+		t.Error("pointer value should be unmarshaled", v.Sales)
+	}
+
+	v.IP = nil
+	err = envlookup.New(envlookup.MapSource{"VENUE_IP": "nowhere"}).Load(&v)
+	var pe *envlookup.ParseError
+	if !errors.As(err, &pe) || pe.Var != "VENUE_IP" {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+}
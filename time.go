@@ -0,0 +1,103 @@
+package envlookup
+
+import (
+	"strconv"
+	"time"
+)
+
+// Pseudo layouts for Time and the TimeLayout option, reading times as
+// the number of seconds or milliseconds since January 1, 1970 UTC.
+const (
+	LayoutUnix      = "unix"
+	LayoutUnixMilli = "unixmilli"
+)
+
+// TimeLayout sets the layout that times are parsed with, as by
+// time.Parse, or one of LayoutUnix and LayoutUnixMilli. The default
+// layout is time.RFC3339.
+func TimeLayout(layout string) Option {
+	return func(e *Env) {
+		e.timeLayout = layout
+	}
+}
+
+// Time retrieves the value of the environment variable named by the
+// key. If the variable is present in the environment the value is
+// parsed with the layout, as by time.Parse, and returned and the error
+// is nil. An empty layout means time.RFC3339, and the layouts
+// LayoutUnix and LayoutUnixMilli read epoch seconds and milliseconds.
+// If the variable is not present but a default value is supplied, that
+// value will be returned. If the env var could not be parsed, ParseError
+// will be returned. Otherwise the returned value will be empty and
+// NotFoundError will be returned.
+func Time(key, layout string, def ...time.Time) (time.Time, error) {
+	return std.Time(key, layout, def...)
+}
+
+// Unix retrieves the value of the environment variable named by the
+// key as a number of seconds since January 1, 1970 UTC. It is
+// equivalent to Time(key, LayoutUnix, def...).
+func Unix(key string, def ...time.Time) (time.Time, error) {
+	return std.Unix(key, def...)
+}
+
+// UnixMilli retrieves the value of the environment variable named by
+// the key as a number of milliseconds since January 1, 1970 UTC. It is
+// equivalent to Time(key, LayoutUnixMilli, def...).
+func UnixMilli(key string, def ...time.Time) (time.Time, error) {
+	return std.UnixMilli(key, def...)
+}
+
+// Location retrieves the value of the environment variable named by
+// the key as a time zone, loaded by time.LoadLocation. Names such as
+// "Europe/Stockholm", "UTC" and "Local" are accepted. Defaults and
+// errors are handled as by Time.
+func Location(key string, def ...*time.Location) (*time.Location, error) {
+	return std.Location(key, def...)
+}
+
+// Time retrieves the value of the variable named by the key from the
+// source of e. See the package-level Time for details.
+func (e *Env) Time(key, layout string, def ...time.Time) (time.Time, error) {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return GetFrom(e.With(TimeLayout(layout)), key, def...)
+}
+
+// Unix retrieves the value of the variable named by the key from the
+// source of e. See the package-level Unix for details.
+func (e *Env) Unix(key string, def ...time.Time) (time.Time, error) {
+	return e.Time(key, LayoutUnix, def...)
+}
+
+// UnixMilli retrieves the value of the variable named by the key from
+// the source of e. See the package-level UnixMilli for details.
+func (e *Env) UnixMilli(key string, def ...time.Time) (time.Time, error) {
+	return e.Time(key, LayoutUnixMilli, def...)
+}
+
+// Location retrieves the value of the variable named by the key from
+// the source of e. See the package-level Location for details.
+func (e *Env) Location(key string, def ...*time.Location) (*time.Location, error) {
+	return GetFrom(e, key, def...)
+}
+
+// parseTime parses v with the time layout of e.
+func (e *Env) parseTime(v string) (time.Time, error) {
+	switch e.timeLayout {
+	case LayoutUnix:
+		sec, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0), nil
+	case LayoutUnixMilli:
+		msec, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(msec), nil
+	}
+	return time.Parse(e.timeLayout, v)
+}
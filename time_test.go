@@ -0,0 +1,90 @@
+package envlookup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var timeEnv = envlookup.New(envlookup.MapSource{
+	"RECORDED_AT":      "1964-12-09T20:00:00-05:00",
+	"RELEASED_ON":      "1965-01-01",
+	"RECORDED_UNIX":    "-universe",
+	"RELEASED_UNIX":    "-157766400",
+	"RELEASED_UNIX_MS": "-157766400000",
+	"STUDIO_TZ":        "America/New_York",
+	"BAD_TZ":           "Mars/Olympus_Mons",
+})
+
+func TestTime(t *testing.T) {
+	at, err := timeEnv.Time("RECORDED_AT", "")
+	want := time.Date(1964, 12, 10, 1, 0, 0, 0, time.UTC)
+	if !at.Equal(want) || err != nil {
+		t.Error("RFC3339 time should be parsed", at, err)
+	}
+
+	on, err := timeEnv.Time("RELEASED_ON", "2006-01-02")
+	want = time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !on.Equal(want) || err != nil {
+		t.Error("time should be parsed with the layout", on, err)
+	}
+
+	_, err = timeEnv.Time("RELEASED_ON", "")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	def := time.Date(1957, 9, 15, 0, 0, 0, 0, time.UTC)
+	at, err = timeEnv.Time("MISSING", "", def)
+	if !at.Equal(def) || err != nil {
+		t.Error("default value should be set", at, err)
+	}
+}
+
+func TestUnix(t *testing.T) {
+	want := time.Date(1965, 1, 1, 0, 0, 0, 0, time.UTC)
+	if at, err := timeEnv.Unix("RELEASED_UNIX"); !at.Equal(want) || err != nil {
+		t.Error("epoch seconds should be parsed", at, err)
+	}
+	if at, err := timeEnv.UnixMilli("RELEASED_UNIX_MS"); !at.Equal(want) || err != nil {
+		t.Error("epoch milliseconds should be parsed", at, err)
+	}
+	if _, err := timeEnv.Unix("RECORDED_UNIX"); err == nil {
+		t.Error("error should be envlookup.ParseError")
+	}
+}
+
+func TestLocation(t *testing.T) {
+	loc, err := timeEnv.Location("STUDIO_TZ")
+	if loc == nil || loc.String() != "America/New_York" || err != nil {
+		t.Skip("time zone database not available", loc, err)
+	}
+
+	_, err = timeEnv.Location("BAD_TZ")
+	if _, ok := err.(*envlookup.ParseError); !ok {
+		t.Error("error should be envlookup.ParseError", err)
+	}
+
+	if loc, err := timeEnv.Location("MISSING", time.UTC); loc != time.UTC || err != nil {
+		t.Error("default value should be set", loc, err)
+	}
+}
+
+func TestLoadTimeLayout(t *testing.T) {
+	var v struct {
+		Recorded time.Time      `env:"RECORDED_AT"`
+		Released time.Time      `env:"RELEASED_ON" layout:"2006-01-02"`
+		Epoch    time.Time      `env:"RELEASED_UNIX" layout:"unix"`
+		Zone     *time.Location `env:"MISSING_TZ" default:"UTC"`
+	}
+	if err := timeEnv.Load(&v); err != nil {
+		t.Fatal("error should be nil", err)
+	}
+	if v.Recorded.Year() != 1964 || v.Released.Year() != 1965 || v.Epoch.Year() != 1965 {
+		t.Error("times should be loaded", v)
+	}
+	if v.Zone != time.UTC {
+		t.Error("location should be loaded", v.Zone)
+	}
+}
@@ -0,0 +1,234 @@
+package envlookup
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError indicates that the value of an environment variable
+// was parsed but did not satisfy a validation rule, see Min, Max,
+// OneOf, Regex, NonEmpty and Validate.
+type ValidationError struct {
+	Var   string
+	Value string
+	Rule  string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("environment variable \"%s\" with value \"%s\" fails rule %s: %s", e.Var, e.Value, e.Rule, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// rule validates a variable value, given both as the raw string and as
+// the parsed value.
+type rule struct {
+	name  string
+	check func(v string, pv reflect.Value) error
+}
+
+// withRule returns an Option adding r to the rules of an Env.
+func withRule(r rule) Option {
+	return func(e *Env) {
+		// Copy the rules so that Envs derived from the same Env
+		// don't share them.
+		e.rules = append(e.rules[:len(e.rules):len(e.rules)], r)
+	}
+}
+
+// Min requires values to be at least min. Numbers are compared by
+// value, with durations in nanoseconds, and strings, slices and maps
+// by length. It is intended for use such as
+//
+//	port, err := envlookup.With(envlookup.Min(1), envlookup.Max(65535)).Int("PORT")
+func Min(min float64) Option {
+	return withRule(rule{"min=" + formatFloat(min), func(_ string, pv reflect.Value) error {
+		n, err := magnitude(pv)
+		if err == nil && n < min {
+			err = fmt.Errorf("must be at least %s", formatFloat(min))
+		}
+		return err
+	}})
+}
+
+// Max requires values to be at most max. Values are compared as by
+// Min.
+func Max(max float64) Option {
+	return withRule(rule{"max=" + formatFloat(max), func(_ string, pv reflect.Value) error {
+		n, err := magnitude(pv)
+		if err == nil && n > max {
+			err = fmt.Errorf("must be at most %s", formatFloat(max))
+		}
+		return err
+	}})
+}
+
+// OneOf requires the raw value of variables to be one of values.
+func OneOf(values ...string) Option {
+	return withRule(rule{"oneof=" + strings.Join(values, " "), func(v string, _ reflect.Value) error {
+		for _, value := range values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(values, ", "))
+	}})
+}
+
+// Regex requires the raw value of variables to match the regular
+// expression expr. The match is unanchored unless expr says otherwise.
+// Regex panics if expr cannot be compiled, like regexp.MustCompile.
+func Regex(expr string) Option {
+	return regexRule(regexp.MustCompile(expr))
+}
+
+func regexRule(re *regexp.Regexp) Option {
+	return withRule(rule{"regex=" + re.String(), func(v string, _ reflect.Value) error {
+		if !re.MatchString(v) {
+			return fmt.Errorf("must match %s", re)
+		}
+		return nil
+	}})
+}
+
+// NonEmpty requires the raw value of variables to be non-empty.
+func NonEmpty() Option {
+	return withRule(rule{"nonempty", func(v string, _ reflect.Value) error {
+		if v == "" {
+			return errors.New("must not be empty")
+		}
+		return nil
+	}})
+}
+
+// Validate adds the custom validation fn for values of type T. Values
+// of other types are not passed to fn. It is intended for use such as
+//
+//	dir, err := envlookup.With(envlookup.Validate(func(dir string) error {
+//		_, err := os.Stat(dir)
+//		return err
+//	})).String("DATA_DIR")
+func Validate[T any](fn func(v T) error) Option {
+	return withRule(rule{"validate", func(_ string, pv reflect.Value) error {
+		if t, ok := pv.Interface().(T); ok {
+			return fn(t)
+		}
+		return nil
+	}})
+}
+
+// validate checks the value v of the variable named by name, parsed as
+// pv, against the rules of e.
+func (e *Env) validate(name, v string, pv reflect.Value) error {
+	for _, r := range e.rules {
+		if err := r.check(v, pv); err != nil {
+			return &ValidationError{name, e.redactValue(v), r.name, e.redactError(err)}
+		}
+	}
+	return nil
+}
+
+// magnitude returns the number that Min and Max compare pv by.
+func magnitude(pv reflect.Value) (float64, error) {
+	switch pv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(pv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(pv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return pv.Float(), nil
+	case reflect.String, reflect.Slice, reflect.Map:
+		return float64(pv.Len()), nil
+	}
+	return 0, fmt.Errorf("cannot compare values of type %s", pv.Type())
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// ruleOptions returns the validation options set by the tags of a
+// struct field.
+func ruleOptions(sf reflect.StructField) []Option {
+	var opts []Option
+	// Bounds of duration fields are durations, with plain numbers in
+	// the unit of the field. An invalid unit is reported by
+	// fieldOptions, and leaves plain numbers rejected.
+	var durations *Env
+	if t := sf.Type; t == durationType || t.Kind() == reflect.Ptr && t.Elem() == durationType {
+		durations = &Env{}
+		if s, ok := sf.Tag.Lookup("unit"); ok {
+			durations.durationUnit, _ = time.ParseDuration("1" + s)
+		}
+	}
+	for _, tag := range []struct {
+		name string
+		opt  func(float64) Option
+	}{{"min", Min}, {"max", Max}} {
+		s, ok := sf.Tag.Lookup(tag.name)
+		if !ok {
+			continue
+		}
+		var f float64
+		var err error
+		if durations != nil {
+			var d time.Duration
+			d, err = durations.parseDuration(s)
+			f = float64(d)
+		} else {
+			f, err = strconv.ParseFloat(s, 64)
+		}
+		if err != nil {
+			opts = append(opts, invalidTag(tag.name, s, err))
+			continue
+		}
+		opts = append(opts, tag.opt(f))
+	}
+	if oneOf, ok := sf.Tag.Lookup("oneof"); ok {
+		opts = append(opts, OneOf(strings.Fields(oneOf)...))
+	}
+	if expr, ok := sf.Tag.Lookup("regex"); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			opts = append(opts, invalidTag("regex", expr, err))
+		} else {
+			opts = append(opts, regexRule(re))
+		}
+	}
+	if tagBool(sf, "nonempty") {
+		opts = append(opts, NonEmpty())
+	}
+	return opts
+}
+
+// invalidTag returns an Option recording that the tag name of a struct
+// field could not be parsed, see checkTags.
+func invalidTag(name, value string, err error) Option {
+	return func(e *Env) {
+		e.badTags = append(e.badTags[:len(e.badTags):len(e.badTags)], rule{name + "=" + value, func(string, reflect.Value) error {
+			return fmt.Errorf("invalid %s tag: %w", name, err)
+		}})
+	}
+}
+
+// checkTags returns ValidationError if a tag of the struct field that
+// the variable named by name is loaded into could not be parsed. It
+// fails whether or not the variable is set.
+func (e *Env) checkTags(name string) error {
+	if len(e.badTags) == 0 {
+		return nil
+	}
+	r := e.badTags[0]
+	return &ValidationError{name, "", r.name, r.check("", reflect.Value{})}
+}
@@ -0,0 +1,140 @@
+package envlookup_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spider-pigs/envlookup"
+)
+
+var validateSrc = envlookup.MapSource{
+	"PORT":      "8080",
+	"BAD_PORT":  "70000",
+	"LOG_LEVEL": "debug",
+	"BAD_LEVEL": "verbose",
+	"NAME":      "trane",
+	"EMPTY":     "",
+	"TIMEOUT":   "500ms",
+}
+
+func TestValidationOptions(t *testing.T) {
+	tests := []struct {
+		key  string
+		opts []envlookup.Option
+		rule string
+	}{
+		{"PORT", []envlookup.Option{envlookup.Min(1), envlookup.Max(65535)}, ""},
+		{"NAME", []envlookup.Option{envlookup.Min(10)}, "min=10"},
+		{"PORT", []envlookup.Option{envlookup.Min(9000)}, "min=9000"},
+		{"LOG_LEVEL", []envlookup.Option{envlookup.OneOf("debug", "info")}, ""},
+		{"BAD_LEVEL", []envlookup.Option{envlookup.OneOf("debug", "info")}, "oneof=debug info"},
+		{"NAME", []envlookup.Option{envlookup.Regex("^[a-z]+$")}, ""},
+		{"PORT", []envlookup.Option{envlookup.Regex("^[a-z]+$")}, "regex=^[a-z]+$"},
+		{"EMPTY", []envlookup.Option{envlookup.NonEmpty()}, "nonempty"},
+		{"NAME", []envlookup.Option{envlookup.Max(3)}, "max=3"},
+	}
+	for _, test := range tests {
+		_, err := envlookup.New(validateSrc, test.opts...).String(test.key)
+		if test.rule == "" {
+			if err != nil {
+				t.Error("error should be nil", test.key, err)
+			}
+			continue
+		}
+		verr, ok := err.(*envlookup.ValidationError)
+		if !ok {
+			t.Error("error should be envlookup.ValidationError", test.key, err)
+			continue
+		}
+		want, _ := validateSrc.Lookup(test.key)
+		if verr.Var != test.key || verr.Value != want || verr.Rule != test.rule {
+			t.Errorf("error should describe the failure: got %+v, want rule %s", verr, test.rule)
+		}
+	}
+
+	port, err := envlookup.New(validateSrc, envlookup.Max(65535)).Int("BAD_PORT")
+	if _, ok := err.(*envlookup.ValidationError); !ok || port != 0 {
+		t.Error("int should be validated by value", port, err)
+	}
+
+	port, err = envlookup.New(validateSrc, envlookup.Min(1)).Int("MISSING", 0)
+	if port != 0 || err != nil {
+		t.Error("default value should not be validated", port, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errOdd := errors.New("must be even")
+	env := envlookup.New(validateSrc, envlookup.Validate(func(i int) error {
+		if i%2 != 0 {
+			return errOdd
+		}
+		return nil
+	}))
+	if port, err := env.Int("PORT"); port != 8080 || err != nil {
+		t.Error("valid value should be returned", port, err)
+	}
+	if s, err := env.String("NAME"); s != "trane" || err != nil {
+		t.Error("values of other types should not be validated", s, err)
+	}
+
+	env = env.With(envlookup.Validate(func(i int) error { return errOdd }))
+	_, err := env.Int("PORT")
+	if !errors.Is(err, errOdd) {
+		t.Error("error should wrap the error of the validation", err)
+	}
+}
+
+func TestLoadValidationTags(t *testing.T) {
+	var v struct {
+		Port    int           `env:"PORT" min:"1" max:"65535"`
+		Level   string        `env:"LOG_LEVEL" oneof:"debug info"`
+		Name    string        `env:"NAME" regex:"^[a-z]+$" nonempty:"true"`
+		Timeout time.Duration `env:"TIMEOUT" min:"100ms"`
+	}
+	if err := envlookup.New(validateSrc).Load(&v); err != nil || v.Port != 8080 {
+		t.Error("valid values should be loaded", v, err)
+	}
+
+	var bad struct {
+		Port    int           `env:"BAD_PORT" max:"65535"`
+		Level   string        `env:"BAD_LEVEL" oneof:"debug info"`
+		Empty   string        `env:"EMPTY" nonempty:"true"`
+		Timeout time.Duration `env:"TIMEOUT" min:"1s"`
+		Name    string        `env:"NAME" regex:"["`
+	}
+	err := envlookup.New(validateSrc).Load(&bad)
+	errs, ok := err.(envlookup.Errors)
+	if !ok || len(errs) != 5 {
+		t.Fatal("every field should fail", err)
+	}
+	for _, err := range errs {
+		if _, ok := err.(*envlookup.ValidationError); !ok {
+			t.Error("error should be envlookup.ValidationError", err)
+		}
+	}
+	if !strings.Contains(errs[4].Error(), "invalid regex tag") {
+		t.Error("error should report the invalid tag", errs[4])
+	}
+}
+
+func TestLoadDurationBoundUnit(t *testing.T) {
+	env := envlookup.New(envlookup.MapSource{"TTL": "10"})
+	var v struct {
+		TTL time.Duration `env:"TTL" unit:"s" min:"30"`
+	}
+	var valErr *envlookup.ValidationError
+	if err := env.Load(&v); !errors.As(err, &valErr) || valErr.Rule != "min=3e+10" {
+		t.Error("bound should be read in the unit of the field", err)
+	}
+
+	var noUnit struct {
+		TTL time.Duration `env:"TTL" min:"30"`
+	}
+	err := env.Load(&noUnit)
+	if !errors.As(err, &valErr) || !strings.Contains(err.Error(), "invalid min tag") {
+		t.Error("bound without unit should be rejected", err)
+	}
+}